@@ -0,0 +1,220 @@
+package base
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"agamigo.io/material/base"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+)
+
+// Adapter wraps an arbitrary MDC JS class so it can be used as the Component of
+// an MDC without a matching agamigo.io/material/* package. It manages the MDC
+// instance's init/destroy, maps the fields of Props tagged with `js:"name"` to
+// properties of the instance, and calls Go callbacks for MDC custom events.
+//
+// A component built on Adapter, such as icontoggle.IT, typically sets itself as
+// Props:
+//
+//	func (c *Foo) Apply(h *vecty.HTML) {
+//		switch {
+//		case c.MDC == nil:
+//			c.MDC = &base.MDC{}
+//			fallthrough
+//		case c.MDC.Component == nil:
+//			c.MDC.Component = &base.Adapter{
+//				Class: "mdc.foo.MDCFoo",
+//				Props: c,
+//				Events: map[string]func(*vecty.Event){
+//					"MDCFoo:change": c.onChange,
+//				},
+//			}
+//		default:
+//			c.MDC.Component.(*base.Adapter).Push()
+//		}
+//		...
+//	}
+type Adapter struct {
+	mdc *base.Component
+
+	// Class is the dot separated path of the MDC class in the global scope,
+	// for example "mdc.foo.MDCFoo".
+	Class string
+
+	// Props is a pointer to a struct. Its exported fields with a js tag are
+	// copied to the MDC instance's properties of the same name when it starts
+	// and by Push, and copied back by Pull. Only bool, string, integer and
+	// float fields are supported.
+	Props interface{}
+
+	// Events maps the names of events emitted by the MDC instance's root
+	// element, for example "MDCMenu:selected", to Go callbacks.
+	Events map[string]func(e *vecty.Event)
+
+	rootElem  *js.Object
+	listeners map[string]*js.Object
+}
+
+// Component implements the material base.Componenter interface.
+func (a *Adapter) Component() *base.Component {
+	if a.mdc == nil {
+		a.mdc = &base.Component{}
+		name := a.Class[strings.LastIndex(a.Class, ".")+1:]
+		a.mdc.Type = base.ComponentType{MDCClassName: name}
+	}
+	return a.mdc.Component()
+}
+
+// MDCClass implements the material base.MDCClasser interface. It returns
+// js.Undefined if Class cannot be found.
+func (a *Adapter) MDCClass() *js.Object {
+	o := js.Global
+	for _, name := range strings.Split(a.Class, ".") {
+		if o == nil || o == js.Undefined {
+			return js.Undefined
+		}
+		o = o.Get(name)
+	}
+	return o
+}
+
+// StateMap implements the material base.StateMapper interface, which makes
+// base.Start restore the Props values on the new MDC instance.
+func (a *Adapter) StateMap() base.StateMap {
+	sm := base.StateMap{}
+	for key, v := range a.props() {
+		sm[key] = v.Interface()
+	}
+	return sm
+}
+
+// Start initializes the MDC instance with rootElem and registers the Events
+// listeners on it.
+func (a *Adapter) Start(rootElem *js.Object) error {
+	if c := a.MDCClass(); c == nil || c == js.Undefined {
+		return errors.New("MDC class " + a.Class + " not found.")
+	}
+	err := base.Start(a, rootElem)
+	if err != nil {
+		return err
+	}
+	a.rootElem = rootElem
+	a.listeners = make(map[string]*js.Object, len(a.Events))
+	for name, fn := range a.Events {
+		fn := fn
+		l := js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+			fn(&vecty.Event{Object: args[0], Target: args[0].Get("target")})
+			return nil
+		})
+		rootElem.Call("addEventListener", name, l)
+		a.listeners[name] = l
+	}
+	return nil
+}
+
+// Stop removes the Events listeners and destroys the MDC instance.
+func (a *Adapter) Stop() error {
+	if a.rootElem != nil {
+		for name, l := range a.listeners {
+			a.rootElem.Call("removeEventListener", name, l)
+		}
+		a.rootElem = nil
+		a.listeners = nil
+	}
+	err := base.Stop(a)
+	a.Component().MDCState.Started = false
+	return err
+}
+
+// Push copies the Props values that differ from the running MDC instance's
+// properties into it. It is a no-op if the instance has not been started.
+func (a *Adapter) Push() {
+	if !a.Component().MDCState.Started {
+		return
+	}
+	o := a.Component().Object
+	for key, v := range a.props() {
+		if propDiffers(o.Get(key), v) {
+			o.Set(key, v.Interface())
+		}
+	}
+}
+
+// Pull copies the running MDC instance's properties into the Props fields. It
+// is a no-op if the instance has not been started.
+func (a *Adapter) Pull() {
+	if !a.Component().MDCState.Started {
+		return
+	}
+	o := a.Component().Object
+	for key, v := range a.props() {
+		p := o.Get(key)
+		if p == js.Undefined {
+			continue
+		}
+		switch v.Kind() {
+		case reflect.Bool:
+			v.SetBool(p.Bool())
+		case reflect.String:
+			v.SetString(p.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64:
+			v.SetInt(p.Int64())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+			reflect.Uint64:
+			v.SetUint(p.Uint64())
+		case reflect.Float32, reflect.Float64:
+			v.SetFloat(p.Float())
+		}
+	}
+}
+
+// props returns the settable js tagged fields of Props, keyed by tag name.
+func (a *Adapter) props() map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	v := reflect.ValueOf(a.Props)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fields
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := f.Tag.Get("js")
+		if key == "" || key == "-" || f.Anonymous || f.PkgPath != "" {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Bool, reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+			reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			fields[key] = v.Field(i)
+		}
+	}
+	return fields
+}
+
+// propDiffers reports whether the JS property p holds a different value than v.
+func propDiffers(p *js.Object, v reflect.Value) bool {
+	if p == nil || p == js.Undefined {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return p.Bool() != v.Bool()
+	case reflect.String:
+		return p.String() != v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return p.Int64() != v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return p.Uint64() != v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return p.Float() != v.Float()
+	}
+	return true
+}
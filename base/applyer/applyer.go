@@ -47,15 +47,28 @@ func FindID(moc vecty.MarkupOrChild) string {
 	return ""
 }
 
+// StartRipple starts the ripple.R applied to h, if any.
 func StartRipple(h *vecty.HTML) {
 	p := findProp("vecty-material-ripple", h)
 	if p == nil {
 		return
 	}
-	p.Get("Start").Invoke()
+	p.Call("Start")
+}
+
+// StopRipple stops the ripple.R applied to h, if any.
+func StopRipple(h *vecty.HTML) {
+	p := findProp("vecty-material-ripple", h)
+	if p == nil {
+		return
+	}
+	p.Call("Stop")
 }
 
 func findProp(key string, h *vecty.HTML) *js.Object {
+	if h == nil {
+		return nil
+	}
 	k := js.InternalObject(h)
 	if k == js.Undefined {
 		return nil
@@ -9,6 +9,7 @@ import (
 type MDC struct {
 	Component   base.ComponentStartStopper
 	RootElement *vecty.HTML
+	started     bool
 }
 
 func (b *MDC) Mount() {
@@ -23,28 +24,118 @@ func (b *MDC) Mount() {
 	if err != nil {
 		panic(err)
 	}
+	b.started = true
 }
 
 func (b *MDC) Unmount() {
-	if b.Component != nil {
+	applyer.StopRipple(b.RootElement)
+	if b.Component != nil && b.started {
 		err := b.Component.Stop()
 		if err != nil {
 			panic(err)
 		}
 	}
+	b.started = false
 }
 
-// MarkupOnly returns the vecty.MarkupList contained in moc, or nil if none is
-// found. It also returns nil if moc is a vecty.List that contains one or more
-// vecty.ComponentOrHTML.  If nil is returned, it is then safe to assert the
-// type of moc as a vecty.ComponentOrHTML.
+// Started reports whether b's MDC component is running, i.e. it has been
+// started on a mounted element and not yet stopped. Components use it during
+// rerenders to decide whether Go field changes must be pushed into the running
+// MDC component.
+func (b *MDC) Started() bool {
+	return b != nil && b.started
+}
+
+// MarkupOnly returns the vecty.MarkupList contained in moc, which may also be a
+// *vecty.MarkupList, or nil if none is found. It also returns nil if moc is a
+// vecty.List or vecty.KeyedList that contains one or more
+// vecty.ComponentOrHTML. A vecty.List without children, e.g. the result of a
+// false vecty.If, returns an empty vecty.MarkupList. If nil is returned, it is
+// then safe to assert the type of moc as a vecty.ComponentOrHTML.
 func MarkupOnly(moc vecty.MarkupOrChild) *vecty.MarkupList {
-	// TODO: handle vecty.List
 	switch t := moc.(type) {
+	case vecty.List:
+		if len(flatten(t)) == 0 {
+			m := vecty.Markup()
+			return &m
+		}
+		return nil
 	case vecty.ComponentOrHTML:
 		return nil
 	case vecty.MarkupList:
 		return &t
+	case *vecty.MarkupList:
+		return t
 	}
 	return nil
 }
+
+// UserRoot returns the element a component renders in place of its built-in
+// root when the user supplied one in a Root, Input or Background field, i.e.
+// when MarkupOnly(moc) returns nil. A vecty.HTML or vecty.Component is returned
+// as is, as is the single member of a vecty.List. Because vecty can not use a
+// list as the root of a component, other lists are rendered as the children of
+// an element with the given tag.
+func UserRoot(tag string, moc vecty.MarkupOrChild) vecty.ComponentOrHTML {
+	switch t := moc.(type) {
+	case vecty.List:
+		l := flatten(t)
+		if len(l) == 1 {
+			return UserRoot(tag, l[0])
+		}
+		return vecty.Tag(tag, l)
+	case vecty.KeyedList:
+		return vecty.Tag(tag, t)
+	case vecty.ComponentOrHTML:
+		return t
+	}
+	return vecty.Tag(tag, moc)
+}
+
+// flatten returns the non-nil members of l, with nested vecty.Lists replaced by
+// their members.
+func flatten(l vecty.List) vecty.List {
+	var flat vecty.List
+	for _, c := range l {
+		switch t := c.(type) {
+		case nil:
+		case vecty.List:
+			flat = append(flat, flatten(t)...)
+		default:
+			flat = append(flat, t)
+		}
+	}
+	return flat
+}
+
+// SyncState implements the controlled/uncontrolled state model shared by every
+// component whose state can be changed by user interaction (checkbox.CB,
+// radio.R, icontoggle.IT, dialog.D, menu.M). Components call it from the event
+// listener that reports a state change made by the user.
+//
+// An uncontrolled component (controlled is false) owns its state. store is
+// called to copy the new state into the component's Go fields, then callback is
+// called and c is rerendered.
+//
+// A controlled component treats its Go fields as the source of truth. callback
+// is called with the Go fields untouched, then restore is called to push the Go
+// fields back into the DOM/MDC instance. A user interaction is therefore only
+// reflected once callback (or its caller) updates the Go fields.
+//
+// Any of store, callback or restore may be nil.
+func SyncState(c vecty.Component, controlled bool,
+	store, callback, restore func()) {
+	if !controlled && store != nil {
+		store()
+	}
+	if callback != nil {
+		callback()
+	}
+	if controlled {
+		if restore != nil {
+			restore()
+		}
+		return
+	}
+	vecty.Rerender(c)
+}
@@ -0,0 +1,158 @@
+package base
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// tabbable selects the elements that may be reached with the Tab key. Elements
+// with a negative tabindex, disabled or hidden elements are filtered out by
+// isTabbable.
+const tabbable = "a[href], area[href], button, input, select, textarea, " +
+	"iframe, [tabindex], [contenteditable=true]"
+
+// FocusTrap keeps the keyboard focus inside an element while it is active, as
+// modal dialogs and drawers require. Deactivating it gives the focus back to
+// the element that had it when the trap was activated. The zero value is an
+// inactive trap.
+type FocusTrap struct {
+	node     *js.Object
+	previous *js.Object
+	keyDown  *js.Object
+	focusIn  *js.Object
+	paused   bool
+}
+
+// Active reports whether the trap is active.
+func (t *FocusTrap) Active() bool {
+	return t.node != nil
+}
+
+// Activate traps the focus inside node. Once node has been rendered, initial is
+// focused. If initial is nil, the focus is moved to the first tabbable element
+// of node, unless it already is inside node.
+func (t *FocusTrap) Activate(node, initial *js.Object) {
+	if t.Active() {
+		t.Deactivate()
+	}
+	t.node = node
+	t.previous = js.Global.Get("document").Get("activeElement")
+	t.keyDown = js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		if !t.paused {
+			t.onKeyDown(args[0])
+		}
+		return nil
+	})
+	t.focusIn = js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		if !t.paused && !t.contains(args[0].Get("target")) {
+			t.focusFirst()
+		}
+		return nil
+	})
+	// The window sees the keydown event before the focus traps of MDC
+	// components listening on the document.
+	js.Global.Call("addEventListener", "keydown", t.keyDown, true)
+	js.Global.Get("document").Call("addEventListener", "focusin", t.focusIn,
+		true)
+	js.Global.Call("requestAnimationFrame", func() {
+		if t.node != node {
+			return
+		}
+		switch {
+		case initial != nil:
+			initial.Call("focus")
+		case !t.contains(js.Global.Get("document").Get("activeElement")):
+			t.focusFirst()
+		}
+	})
+}
+
+// Pause lets the focus leave an active trap, e.g. for another trap opened on
+// top of it, until Resume is called.
+func (t *FocusTrap) Pause() {
+	t.paused = true
+}
+
+// Resume traps the focus again after Pause.
+func (t *FocusTrap) Resume() {
+	t.paused = false
+}
+
+// Deactivate releases the focus and restores it to the element that had it
+// when the trap was activated, if that element is still in the document and
+// the focus has not been moved elsewhere.
+func (t *FocusTrap) Deactivate() {
+	if !t.Active() {
+		return
+	}
+	js.Global.Call("removeEventListener", "keydown", t.keyDown, true)
+	js.Global.Get("document").Call("removeEventListener", "focusin", t.focusIn,
+		true)
+	doc := js.Global.Get("document")
+	active := doc.Get("activeElement")
+	if t.previous != nil && doc.Call("contains", t.previous).Bool() &&
+		(active == nil || active == doc.Get("body") || t.contains(active)) {
+		t.previous.Call("focus")
+	}
+	*t = FocusTrap{}
+}
+
+// onKeyDown moves the focus to the next or previous tabbable element of the
+// trap, wrapping around at its ends.
+func (t *FocusTrap) onKeyDown(e *js.Object) {
+	if e.Get("key").String() != "Tab" || e.Get("defaultPrevented").Bool() {
+		return
+	}
+	nodes := t.tabbables()
+	e.Call("preventDefault")
+	e.Call("stopImmediatePropagation")
+	if len(nodes) == 0 {
+		return
+	}
+	active := js.Global.Get("document").Get("activeElement")
+	i := -1
+	for j, n := range nodes {
+		if n == active {
+			i = j
+			break
+		}
+	}
+	switch {
+	case e.Get("shiftKey").Bool() && i <= 0:
+		i = len(nodes) - 1
+	case e.Get("shiftKey").Bool():
+		i--
+	case i == len(nodes)-1:
+		i = 0
+	default:
+		i++
+	}
+	nodes[i].Call("focus")
+}
+
+func (t *FocusTrap) focusFirst() {
+	if nodes := t.tabbables(); len(nodes) > 0 {
+		nodes[0].Call("focus")
+	}
+}
+
+func (t *FocusTrap) tabbables() []*js.Object {
+	all := t.node.Call("querySelectorAll", tabbable)
+	var nodes []*js.Object
+	for i := 0; i < all.Length(); i++ {
+		if n := all.Index(i); isTabbable(n) {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+func (t *FocusTrap) contains(node *js.Object) bool {
+	return node != nil && (t.node == node || t.node.Call("contains", node).Bool())
+}
+
+func isTabbable(n *js.Object) bool {
+	return n.Get("tabIndex").Int() >= 0 &&
+		!n.Get("disabled").Bool() &&
+		n.Get("type").String() != "hidden" &&
+		n.Call("getClientRects").Length() > 0
+}
@@ -0,0 +1,166 @@
+// mdcevent provides typed Go values for the custom events emitted by MDC
+// components, and helpers to decode them from a *vecty.Event.
+package mdcevent // import "agamigo.io/vecty-material/base/mdcevent"
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+)
+
+// Names of the MDC custom events decoded by this package.
+const (
+	MenuSelectedName          = "MDCMenu:selected"
+	MenuCancelName            = "MDCMenu:cancel"
+	DialogAcceptName          = "MDCDialog:accept"
+	DialogCancelName          = "MDCDialog:cancel"
+	IconToggleChangeName      = "MDCIconToggle:change"
+	TemporaryDrawerOpenName   = "MDCTemporaryDrawer:open"
+	TemporaryDrawerCloseName  = "MDCTemporaryDrawer:close"
+	PersistentDrawerOpenName  = "MDCPersistentDrawer:open"
+	PersistentDrawerCloseName = "MDCPersistentDrawer:close"
+	TabSelectedName           = "MDCTab:selected"
+	TabBarChangeName          = "MDCTabBar:change"
+	TabBarActivatedName       = "MDCTabBar:activated"
+)
+
+// MenuSelected is emitted by a menu when one of its items is selected.
+type MenuSelected struct {
+	*vecty.Event
+
+	// Index is the index of the selected item among the menu's items, as
+	// counted by MDC (dividers are not counted).
+	Index int
+
+	// Item is the selected HTMLElement.
+	Item *js.Object
+}
+
+// MenuCancel is emitted by a menu when it is closed without a selection.
+type MenuCancel struct {
+	*vecty.Event
+}
+
+// DialogAccept is emitted by a dialog when it is closed by its accept action.
+type DialogAccept struct {
+	*vecty.Event
+}
+
+// DialogCancel is emitted by a dialog when it is closed by its cancel action,
+// the escape key or a backdrop click.
+type DialogCancel struct {
+	*vecty.Event
+}
+
+// IconToggleChange is emitted by an icon toggle when it is toggled.
+type IconToggleChange struct {
+	*vecty.Event
+
+	// IsOn is the state of the toggle after the change.
+	IsOn bool
+}
+
+// DrawerOpen is emitted by a temporary or persistent drawer after it opened.
+type DrawerOpen struct {
+	*vecty.Event
+}
+
+// DrawerClose is emitted by a temporary or persistent drawer after it closed.
+type DrawerClose struct {
+	*vecty.Event
+}
+
+// TabActivated is emitted by a tab bar when one of its tabs becomes active.
+type TabActivated struct {
+	*vecty.Event
+
+	// Index is the index of the activated tab.
+	Index int
+}
+
+// DecodeMenuSelected decodes a MenuSelectedName event.
+func DecodeMenuSelected(e *vecty.Event) *MenuSelected {
+	d := detail(e)
+	return &MenuSelected{
+		Event: e,
+		Index: getInt(d, "index"),
+		Item:  get(d, "item"),
+	}
+}
+
+// DecodeMenuCancel decodes a MenuCancelName event.
+func DecodeMenuCancel(e *vecty.Event) *MenuCancel {
+	return &MenuCancel{Event: e}
+}
+
+// DecodeDialogAccept decodes a DialogAcceptName event.
+func DecodeDialogAccept(e *vecty.Event) *DialogAccept {
+	return &DialogAccept{Event: e}
+}
+
+// DecodeDialogCancel decodes a DialogCancelName event.
+func DecodeDialogCancel(e *vecty.Event) *DialogCancel {
+	return &DialogCancel{Event: e}
+}
+
+// DecodeIconToggleChange decodes an IconToggleChangeName event.
+func DecodeIconToggleChange(e *vecty.Event) *IconToggleChange {
+	d := detail(e)
+	return &IconToggleChange{
+		Event: e,
+		IsOn:  d != nil && d.Get("isOn").Bool(),
+	}
+}
+
+// DecodeDrawerOpen decodes a TemporaryDrawerOpenName or
+// PersistentDrawerOpenName event.
+func DecodeDrawerOpen(e *vecty.Event) *DrawerOpen {
+	return &DrawerOpen{Event: e}
+}
+
+// DecodeDrawerClose decodes a TemporaryDrawerCloseName or
+// PersistentDrawerCloseName event.
+func DecodeDrawerClose(e *vecty.Event) *DrawerClose {
+	return &DrawerClose{Event: e}
+}
+
+// DecodeTabActivated decodes a TabBarActivatedName, TabBarChangeName or
+// TabSelectedName event. Index is -1 if the event does not carry the index of
+// the tab.
+func DecodeTabActivated(e *vecty.Event) *TabActivated {
+	d := detail(e)
+	i := -1
+	switch {
+	case get(d, "index") != nil:
+		i = getInt(d, "index")
+	case get(d, "activeTabIndex") != nil:
+		i = getInt(d, "activeTabIndex")
+	}
+	return &TabActivated{Event: e, Index: i}
+}
+
+func detail(e *vecty.Event) *js.Object {
+	if e == nil || e.Object == nil {
+		return nil
+	}
+	return get(e.Object, "detail")
+}
+
+// get returns o[key], or nil if o is nil or o[key] is undefined or null.
+func get(o *js.Object, key string) *js.Object {
+	if o == nil || o == js.Undefined {
+		return nil
+	}
+	v := o.Get(key)
+	if v == js.Undefined || v == nil {
+		return nil
+	}
+	return v
+}
+
+func getInt(o *js.Object, key string) int {
+	v := get(o, key)
+	if v == nil {
+		return -1
+	}
+	return v.Int()
+}
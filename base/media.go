@@ -0,0 +1,36 @@
+package base
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// MediaQuery watches a CSS media query, such as the width of the viewport a
+// responsive component depends on.
+type MediaQuery struct {
+	mql *js.Object
+	fn  *js.Object
+}
+
+// WatchMediaQuery calls onChange with whether query matches each time that
+// changes, until Stop is called.
+func WatchMediaQuery(query string, onChange func(matches bool)) *MediaQuery {
+	m := &MediaQuery{mql: js.Global.Call("matchMedia", query)}
+	m.fn = js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		onChange(m.Matches())
+		return nil
+	})
+	m.mql.Call("addListener", m.fn)
+	return m
+}
+
+// Matches reports whether the query matches.
+func (m *MediaQuery) Matches() bool {
+	return m.mql.Get("matches").Bool()
+}
+
+// Stop stops watching the query. It is a no-op on a nil MediaQuery.
+func (m *MediaQuery) Stop() {
+	if m != nil {
+		m.mql.Call("removeListener", m.fn)
+	}
+}
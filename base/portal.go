@@ -0,0 +1,78 @@
+package base
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+)
+
+// overlayClass is the class of the element holding the layers of portals.
+const overlayClass = "vecty-material-overlay"
+
+// Portal renders Child in a layer of an overlay element attached to the end of
+// the document body, out of ancestors that would clip it with overflow: hidden
+// or stack it below other content with z-index. Dialogs, menus and snackbars
+// use it.
+//
+// The portal still belongs to the component tree it is rendered in. An empty
+// placeholder element takes its place there, and Child is mounted, rerendered
+// and unmounted with its logical parent as if it was rendered in place.
+// Components rendering a Portal should keep the same instance across renders.
+type Portal struct {
+	vecty.Core
+	Child vecty.ComponentOrHTML `vecty:"prop"`
+
+	placeholder *vecty.HTML
+	layer       *vecty.HTML
+}
+
+// Render implements the vecty.Component interface.
+func (c *Portal) Render() vecty.ComponentOrHTML {
+	// vecty only sees the layer as the single child of the placeholder. It
+	// reconciles the layer through its parent node, wherever it has been moved.
+	c.layer = elem.Div(
+		vecty.Markup(vecty.Class("vecty-material-portal")),
+		c.Child,
+	)
+	c.placeholder = elem.Span(
+		vecty.Markup(vecty.Style("display", "none")),
+		c.layer,
+	)
+	return c.placeholder
+}
+
+// Mount implements the vecty.Mounter interface.
+func (c *Portal) Mount() {
+	overlay().Call("appendChild", c.layer.Node())
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *Portal) Unmount() {
+	n := c.layer.Node()
+	if p := n.Get("parentNode"); p != nil {
+		p.Call("removeChild", n)
+	}
+}
+
+// Placeholder returns the element rendered in place of the portal, or nil
+// before the portal is rendered. Its parent is the element the content of the
+// portal would have been rendered in, e.g. to position a menu next to it.
+func (c *Portal) Placeholder() *js.Object {
+	if c.placeholder == nil {
+		return nil
+	}
+	return c.placeholder.Node()
+}
+
+// overlay returns the overlay element, which is created the first time it is
+// needed and whenever the document body has been replaced.
+func overlay() *js.Object {
+	body := js.Global.Get("document").Get("body")
+	o := body.Call("querySelector", ":scope > ."+overlayClass)
+	if o == nil {
+		o = js.Global.Get("document").Call("createElement", "div")
+		o.Get("classList").Call("add", overlayClass)
+		body.Call("appendChild", o)
+	}
+	return o
+}
@@ -1,13 +1,15 @@
 package base
 
 import (
+	"reflect"
+
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 )
 
 type StaticComponent struct {
 	vecty.Core
-	Child vecty.ComponentOrHTML
+	Child vecty.ComponentOrHTML `vecty:"prop"`
 }
 
 // RenderStoredChild is a helper which provides a Component which wraps the
@@ -18,18 +20,46 @@ func RenderStoredChild(child vecty.ComponentOrHTML) *StaticComponent {
 	return &StaticComponent{Child: child}
 }
 
+// RenderStoredChildren is like RenderStoredChild, but a vecty.List is returned
+// as a vecty.List of wrapped members, and a vecty.KeyedList is returned as is,
+// so that lists are rendered into the parent element without a wrapper
+// element.
+func RenderStoredChildren(child vecty.ComponentOrHTML) vecty.ComponentOrHTML {
+	switch t := child.(type) {
+	case vecty.List:
+		l := make(vecty.List, len(t))
+		for i, c := range t {
+			l[i] = RenderStoredChildren(c)
+		}
+		return l
+	case vecty.KeyedList:
+		return t
+	}
+	return RenderStoredChild(child)
+}
+
+// Render implements the vecty.Component interface. A component can not render
+// a list, so a StaticComponent holding a vecty.List or vecty.KeyedList renders
+// it in a div. Use RenderStoredChildren to avoid the extra element.
 func (c *StaticComponent) Render() vecty.ComponentOrHTML {
 	switch t := c.Child.(type) {
-	case vecty.List:
+	case vecty.List, vecty.KeyedList:
 		return elem.Div(t)
 	}
 	return c.Child
 }
 
+// SkipRender implements the vecty.RenderSkipper interface. The child is only
+// rendered again when it was replaced, e.g. by a new dialog Header.
 func (c *StaticComponent) SkipRender(prev vecty.Component) bool {
-	switch prev.(type) {
-	case *StaticComponent:
-		return true
+	p, ok := prev.(*StaticComponent)
+	if !ok {
+		return false
+	}
+	t := reflect.TypeOf(c.Child)
+	if t != reflect.TypeOf(p.Child) {
+		return false
 	}
-	return false
+	// Lists can not be compared, and are kept as they were.
+	return t == nil || !t.Comparable() || c.Child == p.Child
 }
@@ -3,6 +3,7 @@ package button // import "agamigo.io/vecty-material/button"
 
 import (
 	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/ripple"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 	"github.com/gopherjs/vecty/event"
@@ -23,6 +24,11 @@ type B struct {
 	Outlined   bool
 	Dense      bool
 	Href       string
+
+	// Ripple configures the ripple applied to the root element. A default
+	// ripple is created if it is nil, unless NoRipple is set.
+	Ripple   *ripple.R
+	NoRipple bool
 }
 
 // Render implements the vecty.Component interface.
@@ -30,7 +36,7 @@ func (c *B) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("button", c.Root)
 	}
 
 	var ico *vecty.HTML
@@ -52,7 +58,7 @@ func (c *B) Render() vecty.ComponentOrHTML {
 			vecty.MarkupIf(rootMarkup != nil, *rootMarkup),
 		),
 		ico,
-		base.RenderStoredChild(c.Label),
+		base.RenderStoredChildren(c.Label),
 	)
 }
 
@@ -63,6 +69,12 @@ func (c *B) Apply(h *vecty.HTML) {
 	}
 	c.MDC.Component = nil
 	c.MDC.RootElement = h
+	if !c.NoRipple {
+		if c.Ripple == nil {
+			c.Ripple = &ripple.R{}
+		}
+		c.Ripple.Apply(h)
+	}
 	vecty.Markup(
 		vecty.Class("mdc-button"),
 		prop.Type(prop.TypeButton),
@@ -22,6 +22,11 @@ type CB struct {
 	Indeterminate bool
 	Disabled      bool
 	Value         string
+
+	// Controlled makes Checked and Indeterminate the source of truth for the
+	// checkbox state. User interaction only calls OnChange, which should update
+	// the fields and rerender. See base.SyncState.
+	Controlled bool
 }
 
 // Render implements the vecty.Component interface.
@@ -29,14 +34,14 @@ func (c *CB) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("div", c.Root)
 	}
 
 	var bg vecty.ComponentOrHTML
 	bgMarkup := base.MarkupOnly(c.Background)
 	if c.Background != nil && bgMarkup == nil {
 		// User supplied background element.
-		bg = elem.Div(c.Background)
+		bg = base.UserRoot("div", c.Background)
 	} else {
 		// Built-in background element.
 		bg = elem.Div(
@@ -87,6 +92,8 @@ func (c *CB) Apply(h *vecty.HTML) {
 			cb.Disabled = c.Disabled
 			cb.Value = c.Value
 		}
+	default:
+		c.reconcile()
 	}
 
 	vecty.Markup(
@@ -96,23 +103,52 @@ func (c *CB) Apply(h *vecty.HTML) {
 	c.MDC.RootElement = h
 }
 
-func (c *CB) onChange(e *vecty.Event) {
-	if cb, ok := c.MDC.Component.(*checkbox.CB); ok {
-		c.Checked = cb.Checked
-		c.Indeterminate = cb.Indeterminate
-		c.Disabled = cb.Disabled
-		c.Value = cb.Value
+// reconcile pushes changes of the Go fields into the running MDC component.
+func (c *CB) reconcile() {
+	cb, ok := c.MDC.Component.(*checkbox.CB)
+	if !ok || !c.MDC.Started() {
+		return
+	}
+	if cb.Checked != c.Checked {
+		cb.Checked = c.Checked
+	}
+	if cb.Indeterminate != c.Indeterminate {
+		cb.Indeterminate = c.Indeterminate
+	}
+	if cb.Disabled != c.Disabled {
+		cb.Disabled = c.Disabled
 	}
-	if c.OnChange != nil {
-		c.OnChange(c, e)
+	if c.Value != "" && cb.Value != c.Value {
+		cb.Value = c.Value
 	}
 }
 
+func (c *CB) onChange(e *vecty.Event) {
+	base.SyncState(c, c.Controlled,
+		func() {
+			c.Checked = e.Target.Get("checked").Bool()
+			c.Indeterminate = e.Target.Get("indeterminate").Bool()
+		},
+		func() {
+			if c.OnChange != nil {
+				c.OnChange(c, e)
+			}
+		},
+		func() {
+			e.Target.Set("checked", c.Checked)
+			e.Target.Set("indeterminate", c.Indeterminate)
+		},
+	)
+}
+
 func (c *CB) NativeInput() (element *vecty.HTML, id string) {
 	niMarkup := base.MarkupOnly(c.Input)
 	if c.Input != nil && niMarkup == nil {
 		// User supplied input element.
-		element = elem.Input(c.Input)
+		element, _ = base.UserRoot("input", c.Input).(*vecty.HTML)
+		if element == nil {
+			element = elem.Input(c.Input)
+		}
 		id = applyer.FindID(element)
 		return
 	}
@@ -129,7 +165,6 @@ func (c *CB) NativeInput() (element *vecty.HTML, id string) {
 			vecty.Property("disabled", c.Disabled),
 			vecty.Property("indeterminate", c.Indeterminate),
 		),
-		c.Input,
 	)
 	id = applyer.FindID(element)
 	return
@@ -41,7 +41,7 @@ func (c *dialogDemoView) Render() vecty.ComponentOrHTML {
 							prop.ID("mdc-dialog-hero"),
 							applyer.CSSOnly(),
 						),
-						Header: "Are you happy?",
+						Header: vecty.Text("Are you happy?"),
 						Body: vecty.Text("Please check the left and right side " +
 							"of this element for fun."),
 						Open:       true,
@@ -56,7 +56,7 @@ func (c *dialogDemoView) Render() vecty.ComponentOrHTML {
 						Root: vecty.Markup(
 							prop.ID("mdc-dialog-default"),
 						),
-						Header: "Use Google's location service?",
+						Header: vecty.Text("Use Google's location service?"),
 						Body: vecty.Text("Let Google help apps determine " +
 							"location. This means sending anonymous location " +
 							"data to Google, even when no apps are running."),
@@ -68,7 +68,7 @@ func (c *dialogDemoView) Render() vecty.ComponentOrHTML {
 						Root: vecty.Markup(
 							prop.ID("mdc-dialog-colored-footer-buttons"),
 						),
-						Header: "Use Google's location service?",
+						Header: vecty.Text("Use Google's location service?"),
 						Body: vecty.Text("Let Google help apps determine " +
 							"location. This means sending anonymous location " +
 							"data to Google, even when no apps are running."),
@@ -80,9 +80,11 @@ func (c *dialogDemoView) Render() vecty.ComponentOrHTML {
 						Root: vecty.Markup(
 							prop.ID("mdc-dialog-with-list"),
 						),
-						Header:     "Choose a Ringtone",
+						Header:     vecty.Text("Choose a Ringtone"),
 						Role:       "alertdialog",
 						Scrollable: true,
+						// Full-screen on phones.
+						FullScreenBelow: 600,
 						Body: renderList(
 							"None",
 							"Callisto",
@@ -143,6 +145,19 @@ func (c *dialogDemoView) Render() vecty.ComponentOrHTML {
 						}
 					},
 				},
+				&button.B{
+					Label:  vecty.Text("Show Confirm"),
+					Raised: true,
+					OnClick: func(thisB *button.B, e *vecty.Event) {
+						go func() {
+							if <-dialog.Confirm("Discard draft?",
+								"The draft will be lost.") {
+								<-dialog.Alert("Discarded",
+									"The draft was discarded.")
+							}
+						}()
+					},
+				},
 				&formfield.FF{
 					Label: "Toggle RTL",
 					Input: &checkbox.CB{
@@ -164,6 +179,7 @@ func (c *dialogDemoView) Render() vecty.ComponentOrHTML {
 				},
 			),
 		),
+		dialog.Portal,
 	)
 }
 
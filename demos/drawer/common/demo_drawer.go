@@ -3,16 +3,13 @@ package common
 import (
 	"agamigo.io/vecty-material/base"
 	"agamigo.io/vecty-material/drawer"
-	"agamigo.io/vecty-material/icon"
-	"agamigo.io/vecty-material/ul"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 	"github.com/gopherjs/vecty/prop"
 )
 
 func NewDemoDrawer(dType drawer.Type) *drawer.D {
-	var toolbarSpacer *vecty.HTML
-	var header vecty.ComponentOrHTML
+	var toolbarSpacer, header vecty.ComponentOrHTML
 	switch dType {
 	case drawer.Temporary:
 		header = elem.Div(
@@ -25,67 +22,30 @@ func NewDemoDrawer(dType drawer.Type) *drawer.D {
 	case drawer.Persistent:
 		toolbarSpacer = elem.Div()
 	}
-	return &drawer.D{
+	d := &drawer.D{
 		Root: vecty.Markup(
 			vecty.Class("demo-drawer"),
 			prop.ID("demo-drawer"),
 		),
 		Type:          dType,
 		Header:        header,
-		ToolbarSpacer: base.RenderStoredChild(toolbarSpacer),
-		Content: &ul.Group{
-			Lists: []vecty.ComponentOrHTML{
-				&ul.L{
-					Items: []vecty.ComponentOrHTML{
-						iconListItem("inbox", "Inbox"),
-						iconListItem("star", "Star"),
-						iconListItem("send", "Sent Mail"),
-						iconListItem("drafts", "Drafts")},
-					OnClick: func(l *ul.L, i *ul.Item,
-						e *vecty.Event) {
-						for _, v := range l.Items {
-							if ulItem, ok := v.(*ul.Item); ok {
-								switch {
-								case i == ulItem:
-									i.Activated = true
-									vecty.Rerender(i)
-								default:
-									ulItem.Activated = false
-									vecty.Rerender(ulItem)
-								}
-							}
-						}
-					},
-				},
-				ul.ListDivider(),
-				&ul.L{
-					Items: []vecty.ComponentOrHTML{
-						iconListItem("email", "All Mail"),
-						iconListItem("delete", "Trash"),
-						iconListItem("report", "Spam"),
-					}},
-			}},
-	}
-}
-
-func iconListItem(ico, text string) *ul.Item {
-	var selected bool
-	if ico == "inbox" {
-		selected = true
+		ToolbarSpacer: toolbarSpacer,
 	}
-	return &ul.Item{
-		Root: vecty.Markup(
-			vecty.Class("demo-drawer-list-item"),
-		),
-		Selected: selected,
-		Graphic: vecty.List{
-			&icon.I{
-				Root: vecty.Markup(
-					vecty.Attribute("aria-hidden", "true"),
-				),
-				Name: ico,
-			},
+	d.Content = &drawer.Nav{
+		Drawer: d,
+		Sections: []*drawer.NavSection{
+			{Items: []*drawer.NavItem{
+				{Label: "Inbox", Icon: "inbox", Href: "#inbox"},
+				{Label: "Star", Icon: "star", Href: "#star"},
+				{Label: "Sent Mail", Icon: "send", Href: "#sent"},
+				{Label: "Drafts", Icon: "drafts", Href: "#drafts"},
+			}},
+			{Header: "Labels", Items: []*drawer.NavItem{
+				{Label: "All Mail", Icon: "email", Href: "#all"},
+				{Label: "Trash", Icon: "delete", Href: "#trash"},
+				{Label: "Spam", Icon: "report", Href: "#spam"},
+			}},
 		},
-		Primary: vecty.Text(text),
 	}
+	return d
 }
@@ -39,8 +39,7 @@ func (c *drawerDemoView) Render() vecty.ComponentOrHTML {
 				Title:      "Persistent Drawer",
 				Navigation: common.NavMenu,
 				MenuHandler: func(e *vecty.Event) {
-					c.drawer.Open = !c.drawer.Open
-					vecty.Rerender(c.drawer)
+					c.drawer.Toggle()
 				},
 				NoFixed: true,
 			},
@@ -39,8 +39,7 @@ func (c *drawerDemoView) Render() vecty.ComponentOrHTML {
 				Title:      "Temporary Drawer",
 				Navigation: common.NavMenu,
 				MenuHandler: func(e *vecty.Event) {
-					c.drawer.Open = true
-					vecty.Rerender(c.drawer)
+					c.drawer.SetOpen(true)
 				},
 				NoFixed: true,
 			},
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"agamigo.io/vecty-material/base/mdcevent"
 	"agamigo.io/vecty-material/demos/common"
 	"agamigo.io/vecty-material/icon"
 	"agamigo.io/vecty-material/icontoggle"
@@ -70,7 +71,7 @@ func (c *icontoggleDemoView) Render() vecty.ComponentOrHTML {
 								Name: "favorite",
 							},
 							ChangeHandler: func(it *icontoggle.IT,
-								e *vecty.Event) {
+								e *mdcevent.IconToggleChange) {
 								if it.On {
 									favStatus.status = "yes"
 								} else {
@@ -7,7 +7,6 @@ import (
 	"agamigo.io/vecty-material/demos/common"
 	"agamigo.io/vecty-material/formfield"
 	"agamigo.io/vecty-material/icon"
-	"agamigo.io/vecty-material/ripple"
 	"agamigo.io/vecty-material/ul"
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
@@ -437,15 +436,16 @@ func (dl *demoList) makeGraphicExampleItems(l *ul.L, interactive bool) {
 			Primary: vecty.Text("Data Usage"),
 		},
 	}
-	if interactive {
-		for _, cItem := range l.Items {
-			if item, ok := cItem.(*ul.Item); ok {
-				item.Href = "#"
-				item.Root = vecty.Markup(
-					event.Click(nil).PreventDefault(),
-					&ripple.R{},
-				)
+	for _, cItem := range l.Items {
+		if item, ok := cItem.(*ul.Item); ok {
+			if !interactive {
+				item.NoRipple = true
+				continue
 			}
+			item.Href = "#"
+			item.Root = vecty.Markup(
+				event.Click(nil).PreventDefault(),
+			)
 		}
 	}
 }
@@ -489,7 +489,6 @@ func (dl *demoList) makeCheckboxItems(l *ul.L, isLeading bool) {
 		if item, ok := cItem.(*ul.Item); ok {
 			item.Root = vecty.Markup(
 				vecty.Class("checkbox-list-ripple-surface"),
-				&ripple.R{},
 			)
 			if isLeading {
 				item.Graphic = cbs[i]
@@ -5,6 +5,7 @@ import (
 
 	mmenu "agamigo.io/material/menu"
 	"agamigo.io/vecty-material/base/applyer"
+	"agamigo.io/vecty-material/base/mdcevent"
 	"agamigo.io/vecty-material/button"
 	"agamigo.io/vecty-material/checkbox"
 	"agamigo.io/vecty-material/demos/common"
@@ -91,8 +92,9 @@ func (c *MenuDemoView) Render() vecty.ComponentOrHTML {
 		List: &ul.L{},
 	}
 	demoM.List.(*ul.L).Items = c.menuItems
-	demoM.OnSelect = func(index int, item vecty.ComponentOrHTML,
-		e *vecty.Event) {
+	demoM.OnSelect = func(path []int, item vecty.ComponentOrHTML,
+		e *mdcevent.MenuSelected) {
+		index := path[0]
 		c.SelectedIndex = index
 		for _, lItem := range demoM.List.(*ul.L).Items {
 			if ulItem, ok := lItem.(*ul.Item); ok {
@@ -167,21 +169,22 @@ func (c *MenuDemoView) Render() vecty.ComponentOrHTML {
 		if thisR.Checked {
 			switch thisR.Value {
 			case "top start":
-				demoM.SetAnchorCorner(mmenu.TOP_START)
+				demoM.Corner = mmenu.TOP_START
 			case "top end":
-				demoM.SetAnchorCorner(mmenu.TOP_END)
+				demoM.Corner = mmenu.TOP_END
 			case "bottom start":
-				demoM.SetAnchorCorner(mmenu.BOTTOM_START)
+				demoM.Corner = mmenu.BOTTOM_START
 			case "bottom end":
-				demoM.SetAnchorCorner(mmenu.BOTTOM_END)
+				demoM.Corner = mmenu.BOTTOM_END
 			}
+			vecty.Rerender(demoM)
 		}
 	}
 
 	menuMarginFunc := func(e *vecty.Event) {
 		id := e.Target.Get("id").String()
 		val := e.Target.Get("value").Int()
-		m := demoM.AnchorMargins()
+		m := &demoM.Margins
 		switch id {
 		case "top-margin":
 			m.Top = val
@@ -192,7 +195,6 @@ func (c *MenuDemoView) Render() vecty.ComponentOrHTML {
 		case "right-margin":
 			m.Right = val
 		}
-		demoM.SetAnchorMargins(m)
 		vecty.Rerender(c)
 	}
 
@@ -0,0 +1,179 @@
+package dialog
+
+import (
+	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/button"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+)
+
+// ActionRole is the role of a dialog action.
+type ActionRole int
+
+const (
+	// NeutralAction actions, e.g. "Learn more", have no special meaning.
+	NeutralAction ActionRole = iota
+
+	// AcceptAction actions accept the dialog. Clicking one closes the dialog
+	// with a DialogAccept event, which calls OnAccept.
+	AcceptAction
+
+	// CancelAction actions cancel the dialog. Clicking one closes the dialog
+	// with a DialogCancel event, which calls OnCancel.
+	CancelAction
+
+	// DestructiveAction actions, e.g. "Delete", are colored as errors.
+	DestructiveAction
+)
+
+// Action is a button in the footer of a dialog.
+type Action struct {
+	Label string
+	Role  ActionRole
+
+	// DefaultFocus focuses the action when the dialog opens. By default the
+	// first AcceptAction is focused.
+	DefaultFocus bool
+
+	Disabled bool
+
+	// KeepOpen keeps the dialog open when the action is clicked.
+	KeepOpen bool
+
+	btn   *button.B
+	owned bool
+}
+
+// Button returns the button.B rendering the action, or nil before the dialog
+// rendered it.
+func (a *Action) Button() *button.B {
+	return a.btn
+}
+
+// actions returns the Actions of the dialog, or its default Cancel and Accept
+// actions made from CancelBtn and AcceptBtn if Actions is nil.
+func (c *D) actions() []*Action {
+	if c.Actions != nil {
+		return c.Actions
+	}
+	if c.defaultActions == nil {
+		c.defaultActions = []*Action{
+			{Label: "Cancel", Role: CancelAction},
+			{Label: "Accept", Role: AcceptAction},
+		}
+	}
+	for i, b := range []*button.B{c.CancelBtn, c.AcceptBtn} {
+		a := c.defaultActions[i]
+		if b != nil && a.btn != b {
+			a.btn = b
+			a.owned = false
+		}
+	}
+	return c.defaultActions
+}
+
+// renderActions returns the footer of the dialog, or nil if it has no actions.
+func (c *D) renderActions() vecty.ComponentOrHTML {
+	actions := c.actions()
+	if len(actions) == 0 {
+		return nil
+	}
+	footer := []vecty.MarkupOrChild{
+		vecty.Markup(
+			vecty.Class("mdc-dialog__footer"),
+			vecty.MarkupIf(c.StackedActions,
+				vecty.Style("flex-direction", "column"),
+				vecty.Style("align-items", "flex-end"),
+			),
+			event.Click(c.onActionClick),
+		),
+	}
+	for _, a := range actions {
+		footer = append(footer, c.renderAction(a))
+	}
+	return elem.Footer(footer...)
+}
+
+func (c *D) renderAction(a *Action) *button.B {
+	if a.btn == nil {
+		a.btn = &button.B{}
+		a.owned = true
+	}
+	b := a.btn
+	closeClass := ""
+	switch {
+	case a.KeepOpen:
+	case a.Role == AcceptAction:
+		closeClass = "mdc-dialog__footer__button--accept"
+	case a.Role == CancelAction:
+		closeClass = "mdc-dialog__footer__button--cancel"
+	}
+	markup := vecty.Markup(
+		vecty.Class("mdc-dialog__footer__button"),
+		vecty.MarkupIf(closeClass != "", vecty.Class(closeClass)),
+		vecty.MarkupIf(a.Role == DestructiveAction,
+			vecty.Style("color", "var(--mdc-theme-error, #b00020)")),
+		vecty.MarkupIf(c.StackedActions,
+			vecty.Style("margin", "4px 0")),
+	)
+	if a.owned {
+		b.Label = vecty.Text(a.Label)
+		b.Root = markup
+		b.Disabled = a.Disabled
+		return b
+	}
+	// CancelBtn and AcceptBtn keep the label and markup they were given.
+	if b.Label == nil {
+		b.Label = vecty.Text(a.Label)
+	}
+	if b.Root == nil {
+		b.Root = markup
+	}
+	return b
+}
+
+func (c *D) onActionClick(e *vecty.Event) {
+	for _, a := range c.actions() {
+		b := a.btn
+		if b == nil || b.MDC == nil || b.MDC.RootElement == nil {
+			continue
+		}
+		n := b.MDC.RootElement.Node()
+		if n == e.Target || n.Call("contains", e.Target).Bool() {
+			c.onAction(a, e)
+			return
+		}
+	}
+}
+
+// onAction calls OnAction, then closes the dialog unless the action keeps it
+// open. Accept and cancel actions are closed by MDC.
+func (c *D) onAction(a *Action, e *vecty.Event) {
+	if a.Disabled {
+		return
+	}
+	if c.OnAction != nil {
+		c.OnAction(c, a, e)
+	}
+	if a.KeepOpen || a.Role == AcceptAction || a.Role == CancelAction {
+		return
+	}
+	base.SyncState(c, c.Controlled,
+		func() { c.Open = false },
+		func() { c.closed(CloseAction) },
+		nil,
+	)
+}
+
+// defaultFocus returns the element of the action with DefaultFocus, or nil.
+func (c *D) defaultFocus() *js.Object {
+	for _, a := range c.actions() {
+		b := a.btn
+		if a.DefaultFocus && b != nil && b.MDC != nil && b.MDC.RootElement != nil {
+			return b.MDC.RootElement.Node()
+		}
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"agamigo.io/material/dialog"
 	"agamigo.io/vecty-material/base"
 	"agamigo.io/vecty-material/base/applyer"
+	"agamigo.io/vecty-material/base/mdcevent"
 	"agamigo.io/vecty-material/button"
+	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 	"github.com/gopherjs/vecty/event"
@@ -17,7 +19,7 @@ type D struct {
 	*base.MDC
 	vecty.Core
 	Root       vecty.MarkupOrChild
-	Header     string
+	Header     vecty.ComponentOrHTML
 	Body       vecty.ComponentOrHTML
 	Role       string
 	Open       bool
@@ -25,48 +27,78 @@ type D struct {
 	Scrollable bool
 	AcceptBtn  *button.B
 	CancelBtn  *button.B
-	OnAccept   func(this *D, e *vecty.Event)
-	OnCancel   func(this *D, e *vecty.Event)
+	OnAccept   func(this *D, e *mdcevent.DialogAccept)
+	OnCancel   func(this *D, e *mdcevent.DialogCancel)
+
+	// Actions are the buttons in the footer of the dialog. If Actions is nil,
+	// the dialog has a Cancel and an Accept action rendered with CancelBtn and
+	// AcceptBtn. Set it to an empty slice for a dialog without actions.
+	Actions []*Action
+
+	// StackedActions lays the actions out vertically, for long labels.
+	StackedActions bool
+
+	// OnAction is called when an action is clicked, before the dialog closes.
+	OnAction func(this *D, action *Action, e *vecty.Event)
+
+	// FullScreen makes the dialog cover the viewport, with a top bar holding a
+	// close button, the header and the actions other than cancel actions.
+	FullScreen bool
+
+	// FullScreenBelow makes the dialog full-screen while the viewport is
+	// narrower than FullScreenBelow pixels.
+	FullScreenBelow int
+
+	// NoEscapeClose and NoBackdropClose keep the dialog open when the escape
+	// key is pressed or its backdrop is clicked. Only the topmost of the open
+	// dialogs closes on those.
+	NoEscapeClose   bool
+	NoBackdropClose bool
+
+	// OnClose is called when the dialog is closed by the user, after OnAccept,
+	// OnCancel or OnAction, with the reason it closed.
+	OnClose func(this *D, reason CloseReason)
+
+	// Overlay renders the dialog in the overlay layer at the end of the
+	// document body instead of in place. See base.Portal.
+	Overlay bool
+
+	// InitialFocus is the element focused when the dialog opens. By default it
+	// is the action with DefaultFocus, or the first accept action.
+	InitialFocus *vecty.HTML
+
+	// Controlled makes Open the source of truth for the dialog's visibility.
+	// Accepting or cancelling the dialog only calls OnAccept/OnCancel, which
+	// should update Open and rerender. See base.SyncState.
+	Controlled bool
+
+	defaultActions []*Action
+	trap           base.FocusTrap
+	narrow         bool
+	breakpoint     *breakpoint
+	portal         *base.Portal
+
+	closeReason     CloseReason
+	backdropClicked bool
 }
 
 // Render implements the vecty.Component interface.
 func (c *D) Render() vecty.ComponentOrHTML {
-	rootMarkup := base.MarkupOnly(c.Root)
-	if c.Root != nil && rootMarkup == nil {
-		// User supplied root element.
-		return elem.Aside(c.Root)
+	if !c.Overlay {
+		return c.render()
 	}
-
-	// TODO: Make action buttons a type
-	cancelButton := c.CancelBtn
-	if cancelButton == nil {
-		cancelButton = &button.B{}
-	}
-	if cancelButton.Label == nil {
-		cancelButton.Label = vecty.Text("Cancel")
-	}
-	if cancelButton.Root == nil {
-		cancelButton.Root = vecty.Markup(
-			vecty.Class("mdc-dialog__footer__button"),
-			vecty.Class("mdc-dialog__footer__button--cancel"),
-			event.Click(c.onCancel),
-		)
+	if c.portal == nil {
+		c.portal = &base.Portal{}
 	}
+	c.portal.Child = c.render()
+	return c.portal
+}
 
-	// TODO: Make action buttons a type
-	acceptButton := c.AcceptBtn
-	if acceptButton == nil {
-		acceptButton = &button.B{}
-	}
-	if acceptButton.Label == nil {
-		acceptButton.Label = vecty.Text("Accept")
-	}
-	if acceptButton.Root == nil {
-		acceptButton.Root = vecty.Markup(
-			vecty.Class("mdc-dialog__footer__button"),
-			vecty.Class("mdc-dialog__footer__button--accept"),
-			event.Click(c.onAccept),
-		)
+func (c *D) render() vecty.ComponentOrHTML {
+	rootMarkup := base.MarkupOnly(c.Root)
+	if c.Root != nil && rootMarkup == nil {
+		// User supplied root element.
+		return base.UserRoot("aside", c.Root)
 	}
 
 	h := elem.Aside(
@@ -75,6 +107,12 @@ func (c *D) Render() vecty.ComponentOrHTML {
 		),
 	)
 
+	full := c.fullScreen()
+	var footer vecty.ComponentOrHTML
+	if !full {
+		footer = c.renderActions()
+	}
+
 	// Built-in root element.
 	return elem.Aside(
 		vecty.Markup(
@@ -84,36 +122,29 @@ func (c *D) Render() vecty.ComponentOrHTML {
 		elem.Div(
 			vecty.Markup(
 				vecty.Class("mdc-dialog__surface"),
-			),
-			elem.Header(
-				vecty.Markup(
-					vecty.Class("mdc-dialog__header"),
-				),
-				elem.Heading2(
-					vecty.Markup(
-						vecty.Class("mdc-dialog__header__title"),
-						vecty.MarkupIf(c.labelID(h) != "",
-							prop.ID(c.labelID(h))),
-					),
-					vecty.Text(c.Header),
+				vecty.MarkupIf(full,
+					vecty.Style("width", "100%"),
+					vecty.Style("height", "100%"),
+					vecty.Style("min-width", "0"),
+					vecty.Style("max-width", "none"),
+					vecty.Style("border-radius", "0"),
 				),
 			),
+			c.renderHeader(h, full),
 			elem.Section(
 				vecty.Markup(
 					prop.ID(c.descriptionID(h)),
 					vecty.Class("mdc-dialog__body"),
 					vecty.MarkupIf(c.Scrollable,
 						vecty.Class("mdc-dialog__body--scrollable")),
+					vecty.MarkupIf(full,
+						vecty.Style("flex", "1"),
+						vecty.Style("overflow", "auto"),
+					),
 				),
-				base.RenderStoredChild(c.Body),
-			),
-			elem.Footer(
-				vecty.Markup(
-					vecty.Class("mdc-dialog__footer"),
-				),
-				cancelButton,
-				acceptButton,
+				base.RenderStoredChildren(c.Body),
 			),
+			footer,
 		),
 		vecty.If(!c.NoBackdrop,
 			elem.Div(
@@ -132,8 +163,10 @@ func (c *D) Apply(h *vecty.HTML) {
 		fallthrough
 	case c.MDC.Component == nil:
 		c.MDC.Component = dialog.New()
+		c.MDC.Component.(*dialog.D).Open = c.Open
+	default:
+		c.reconcile()
 	}
-	c.MDC.Component.(*dialog.D).Open = c.Open
 	vecty.Markup(
 		vecty.Class("mdc-dialog"),
 		vecty.MarkupIf(c.Role == "", vecty.Attribute("role", "dialog")),
@@ -142,10 +175,70 @@ func (c *D) Apply(h *vecty.HTML) {
 		vecty.MarkupIf(!c.Open, vecty.Attribute("aria-hidden", "true")),
 		c.ariaLabelledBy(h),
 		c.ariaDescribedBy(h),
+		event.Click(c.onBackdropClick),
+		&vecty.EventListener{
+			Name:     mdcevent.DialogAcceptName,
+			Listener: c.onAccept,
+		},
+		&vecty.EventListener{
+			Name:     mdcevent.DialogCancelName,
+			Listener: c.onCancel,
+		},
 	).Apply(h)
 	c.MDC.RootElement = h
 }
 
+// Mount implements the vecty.Mounter interface.
+func (c *D) Mount() {
+	c.MDC.Mount()
+	if c.MDC.Started() {
+		c.patchCancel()
+	}
+	c.reconcileBreakpoint()
+	c.reconcileFocus()
+	c.reconcileStack()
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *D) Unmount() {
+	c.trap.Deactivate()
+	c.removeFromStack()
+	c.breakpoint.remove()
+	c.breakpoint = nil
+	c.MDC.Unmount()
+}
+
+// reconcile pushes changes of the Go fields into the running MDC component,
+// which opens or closes the dialog with its animation.
+func (c *D) reconcile() {
+	c.reconcileBreakpoint()
+	d, ok := c.MDC.Component.(*dialog.D)
+	if !ok || !c.MDC.Started() {
+		return
+	}
+	// Trap the focus before MDC moves it, to remember which element had it.
+	c.reconcileFocus()
+	c.reconcileStack()
+	if d.Open != c.Open {
+		d.Open = c.Open
+	}
+}
+
+// reconcileFocus traps the focus inside the open dialog, and restores it to
+// the element that opened the dialog once it is closed.
+func (c *D) reconcileFocus() {
+	switch open := c.Open && c.MDC.Started(); {
+	case open && !c.trap.Active():
+		initial := c.defaultFocus()
+		if c.InitialFocus != nil {
+			initial = c.InitialFocus.Node()
+		}
+		c.trap.Activate(c.MDC.RootElement.Node(), initial)
+	case !open && c.trap.Active():
+		c.trap.Deactivate()
+	}
+}
+
 func (c *D) labelID(h *vecty.HTML) string {
 	id := applyer.FindID(h)
 	if id == "" {
@@ -177,19 +270,56 @@ func (c *D) ariaDescribedBy(h *vecty.HTML) vecty.Applyer {
 }
 
 func (c *D) onCancel(e *vecty.Event) {
-	if d, ok := c.MDC.Component.(*dialog.D); ok {
-		c.Open = d.Open
-	}
-	if c.OnCancel != nil {
-		c.OnCancel(c, e)
-	}
+	reason := c.closeReason
+	c.closeReason = CloseCancel
+	base.SyncState(c, c.Controlled,
+		c.storeClosed,
+		func() {
+			if c.OnCancel != nil {
+				c.OnCancel(c, mdcevent.DecodeDialogCancel(e))
+			}
+			c.closed(reason)
+		},
+		c.restoreLater,
+	)
 }
 
 func (c *D) onAccept(e *vecty.Event) {
-	if d, ok := c.MDC.Component.(*dialog.D); ok {
-		c.Open = d.Open
-	}
-	if c.OnAccept != nil {
-		c.OnAccept(c, e)
+	base.SyncState(c, c.Controlled,
+		c.storeClosed,
+		func() {
+			if c.OnAccept != nil {
+				c.OnAccept(c, mdcevent.DecodeDialogAccept(e))
+			}
+			c.closed(CloseAccept)
+		},
+		c.restoreLater,
+	)
+}
+
+func (c *D) closed(reason CloseReason) {
+	if c.OnClose != nil {
+		c.OnClose(c, reason)
 	}
 }
+
+// storeClosed records that the dialog closed. MDC sends the accept and cancel
+// events before it closes the dialog, so its state can't be read yet. The
+// dialog leaves the stack right away, so that the next escape key press goes
+// to the dialog below it even before the rerender.
+func (c *D) storeClosed() {
+	c.Open = false
+	c.reconcileFocus()
+	c.reconcileStack()
+}
+
+// restoreLater pushes Open back into the MDC component once MDC has closed the
+// dialog after an accept or cancel event, so that a controlled dialog stays
+// open unless Open was cleared.
+func (c *D) restoreLater() {
+	js.Global.Call("setTimeout", func() {
+		if d, ok := c.MDC.Component.(*dialog.D); ok && d.Open != c.Open {
+			d.Open = c.Open
+		}
+	}, 0)
+}
@@ -0,0 +1,109 @@
+package dialog
+
+import (
+	"strconv"
+
+	"agamigo.io/vecty-material/base"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+	"github.com/gopherjs/vecty/prop"
+)
+
+// breakpoint watches the media query of FullScreenBelow.
+type breakpoint struct {
+	width int
+	mq    *base.MediaQuery
+}
+
+func (b *breakpoint) remove() {
+	if b != nil {
+		b.mq.Stop()
+	}
+}
+
+func (c *D) fullScreen() bool {
+	return c.FullScreen || c.narrow
+}
+
+// reconcileBreakpoint listens to the viewport width when FullScreenBelow is
+// set, switching the dialog between modal and full-screen.
+func (c *D) reconcileBreakpoint() {
+	if c.breakpoint != nil && c.breakpoint.width == c.FullScreenBelow {
+		return
+	}
+	c.breakpoint.remove()
+	c.breakpoint = nil
+	narrow := false
+	if c.FullScreenBelow > 0 {
+		b := &breakpoint{width: c.FullScreenBelow}
+		b.mq = base.WatchMediaQuery(
+			"(max-width: "+strconv.Itoa(c.FullScreenBelow-1)+"px)",
+			func(matches bool) {
+				c.narrow = matches
+				vecty.Rerender(c)
+			})
+		c.breakpoint = b
+		narrow = b.mq.Matches()
+	}
+	if narrow != c.narrow {
+		c.narrow = narrow
+		vecty.Rerender(c)
+	}
+}
+
+// renderHeader returns the header of the dialog. A full-screen dialog has a top
+// bar instead, with a close button and its actions other than cancel actions.
+func (c *D) renderHeader(h *vecty.HTML, full bool) vecty.ComponentOrHTML {
+	if c.Header == nil && !full {
+		return nil
+	}
+	title := elem.Heading2(
+		vecty.Markup(
+			vecty.Class("mdc-dialog__header__title"),
+			vecty.MarkupIf(c.labelID(h) != "", prop.ID(c.labelID(h))),
+			vecty.MarkupIf(full,
+				vecty.Style("flex", "1"),
+				vecty.Style("margin", "0 16px"),
+			),
+		),
+		vecty.If(c.Header != nil, base.RenderStoredChildren(c.Header)),
+	)
+	if !full {
+		return elem.Header(
+			vecty.Markup(vecty.Class("mdc-dialog__header")),
+			title,
+		)
+	}
+
+	bar := []vecty.MarkupOrChild{
+		vecty.Markup(
+			vecty.Class("mdc-dialog__header"),
+			vecty.Style("display", "flex"),
+			vecty.Style("align-items", "center"),
+			vecty.Style("padding", "8px"),
+			event.Click(c.onActionClick),
+		),
+		elem.Button(
+			vecty.Markup(
+				vecty.Class("material-icons"),
+				// MDC cancels the dialog when this button is clicked.
+				vecty.Class("mdc-dialog__footer__button--cancel"),
+				vecty.Attribute("aria-label", "Close"),
+				vecty.Style("border", "none"),
+				vecty.Style("background", "none"),
+				vecty.Style("color", "inherit"),
+				vecty.Style("cursor", "pointer"),
+				vecty.Style("padding", "8px"),
+			),
+			vecty.Text("close"),
+		),
+		title,
+	}
+	for _, a := range c.actions() {
+		if a.Role != CancelAction {
+			bar = append(bar, c.renderAction(a))
+		}
+	}
+	return elem.Header(bar...)
+}
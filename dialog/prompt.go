@@ -0,0 +1,174 @@
+package dialog
+
+import (
+	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/base/mdcevent"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+	"github.com/gopherjs/vecty/prop"
+)
+
+// closeDelay is the time in milliseconds a closed dialog stays mounted, so that
+// MDC can run its closing animation.
+const closeDelay = 300
+
+// Portal is the container Alert, Confirm and Prompt mount their dialogs into.
+// An app using them renders Portal once, anywhere in its body, otherwise they
+// are cancelled right away. The dialogs are rendered in the overlay layer, see
+// base.Portal.
+var Portal = &Container{}
+
+// Container renders dialogs opened imperatively. See Portal.
+type Container struct {
+	vecty.Core
+	dialogs []*D
+	portal  base.Portal
+	mounted bool
+}
+
+// Render implements the vecty.Component interface.
+func (c *Container) Render() vecty.ComponentOrHTML {
+	dialogs := []vecty.MarkupOrChild{
+		vecty.Markup(vecty.Class("vecty-material-dialog-portal")),
+	}
+	for _, d := range c.dialogs {
+		dialogs = append(dialogs, vecty.List{d}.WithKey(d))
+	}
+	c.portal.Child = elem.Div(dialogs...)
+	return &c.portal
+}
+
+// Mount implements the vecty.Mounter interface.
+func (c *Container) Mount() {
+	c.mounted = true
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *Container) Unmount() {
+	c.mounted = false
+}
+
+// show mounts d open into c. done is called once with whether d was accepted,
+// when it is accepted or cancelled, including with the escape key or a click
+// on the backdrop. d is unmounted after its closing animation. If c is not
+// rendered, an error is logged and d is cancelled right away.
+func (c *Container) show(d *D, done func(accepted bool)) {
+	if !c.mounted {
+		js.Global.Get("console").Call("error",
+			"vecty-material: dialog.Portal must be rendered to open dialogs")
+		done(false)
+		return
+	}
+	closed := false
+	finish := func(accepted bool) {
+		if closed {
+			return
+		}
+		closed = true
+		done(accepted)
+		js.Global.Call("setTimeout", func() { c.remove(d) }, closeDelay)
+	}
+	d.Open = true
+	d.OnAccept = func(_ *D, _ *mdcevent.DialogAccept) { finish(true) }
+	d.OnCancel = func(_ *D, _ *mdcevent.DialogCancel) { finish(false) }
+	c.dialogs = append(c.dialogs, d)
+	vecty.Rerender(c)
+}
+
+func (c *Container) remove(d *D) {
+	for i, other := range c.dialogs {
+		if other == d {
+			c.dialogs = append(c.dialogs[:i], c.dialogs[i+1:]...)
+			if c.mounted {
+				vecty.Rerender(c)
+			}
+			return
+		}
+	}
+}
+
+// Alert opens a dialog with an OK action in Portal. The returned channel is
+// closed when the dialog is dismissed.
+func Alert(title, body string) <-chan struct{} {
+	result := make(chan struct{})
+	d := &D{
+		Header:  vecty.Text(title),
+		Body:    vecty.Text(body),
+		Role:    "alertdialog",
+		Actions: []*Action{{Label: "OK", Role: AcceptAction}},
+	}
+	Portal.show(d, func(bool) { close(result) })
+	return result
+}
+
+// Confirm opens a dialog with Cancel and OK actions in Portal. The returned
+// channel receives whether the dialog was accepted. Dismissing the dialog with
+// the escape key or a click on the backdrop cancels it.
+func Confirm(title, body string) <-chan bool {
+	result := make(chan bool, 1)
+	d := &D{
+		Header: vecty.Text(title),
+		Body:   vecty.Text(body),
+		Role:   "alertdialog",
+		Actions: []*Action{
+			{Label: "Cancel", Role: CancelAction},
+			{Label: "OK", Role: AcceptAction},
+		},
+	}
+	Portal.show(d, func(accepted bool) { result <- accepted })
+	return result
+}
+
+// Prompt opens a dialog asking for a line of text in Portal, with value as
+// the initial text. The returned channel receives the text if the dialog is
+// accepted, and is closed without a value if it is cancelled:
+//
+//	if name, ok := <-dialog.Prompt("Rename", "Name", name); ok {
+//		...
+//	}
+func Prompt(title, label, value string) <-chan string {
+	result := make(chan string, 1)
+	ok := &Action{Label: "OK", Role: AcceptAction}
+	d := &D{
+		Header:  vecty.Text(title),
+		Actions: []*Action{{Label: "Cancel", Role: CancelAction}, ok},
+	}
+	input := elem.Input(
+		vecty.Markup(
+			vecty.Class("mdc-text-field__input"),
+			prop.Type(prop.TypeText),
+			prop.Value(value),
+			vecty.Attribute("placeholder", label),
+			vecty.Attribute("aria-label", label),
+			event.Input(func(e *vecty.Event) {
+				value = e.Target.Get("value").String()
+			}),
+			event.KeyDown(func(e *vecty.Event) {
+				if e.Get("key").String() == "Enter" {
+					// Accept the dialog the way MDC does.
+					e.Call("preventDefault")
+					if b := ok.Button(); b != nil && b.MDC != nil {
+						b.MDC.RootElement.Node().Call("click")
+					}
+				}
+			}),
+		),
+	)
+	d.Body = elem.Div(
+		vecty.Markup(
+			vecty.Class("mdc-text-field"),
+			vecty.Class("mdc-text-field--fullwidth"),
+		),
+		input,
+	)
+	d.InitialFocus = input
+	Portal.show(d, func(accepted bool) {
+		if accepted {
+			result <- value
+		}
+		close(result)
+	})
+	return result
+}
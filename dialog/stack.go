@@ -0,0 +1,200 @@
+package dialog
+
+import (
+	"agamigo.io/material/dialog"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+)
+
+// CloseReason is the reason a dialog closed, reported to OnClose.
+type CloseReason int
+
+const (
+	// CloseAccept dialogs were closed by an accept action.
+	CloseAccept CloseReason = iota
+
+	// CloseCancel dialogs were closed by a cancel action.
+	CloseCancel
+
+	// CloseEscape dialogs were closed with the escape key.
+	CloseEscape
+
+	// CloseBackdrop dialogs were closed by a click on their backdrop.
+	CloseBackdrop
+
+	// CloseAction dialogs were closed by a neutral or destructive action.
+	CloseAction
+)
+
+func (r CloseReason) String() string {
+	switch r {
+	case CloseAccept:
+		return "accept"
+	case CloseCancel:
+		return "cancel"
+	case CloseEscape:
+		return "escape"
+	case CloseBackdrop:
+		return "backdrop"
+	case CloseAction:
+		return "action"
+	}
+	return "unknown"
+}
+
+var (
+	// stack holds the open dialogs, the topmost last.
+	stack []*D
+
+	// hidden holds the elements stack set aria-hidden on.
+	hidden []*js.Object
+
+	// escapeListener marks the escape key presses MDC cancels dialogs on.
+	escapeListener *js.Object
+
+	// escaping is the topmost dialog when an escape key press is dispatched,
+	// the only one it may close. It is nil otherwise.
+	escaping *D
+)
+
+// top reports whether c is the topmost open dialog.
+func (c *D) top() bool {
+	return len(stack) > 0 && stack[len(stack)-1] == c
+}
+
+// reconcileStack pushes c on the stack of open dialogs when it opens, and
+// removes it when it closes. Only the topmost dialog traps the focus, and the
+// rest of the page is hidden from assistive technologies.
+func (c *D) reconcileStack() {
+	open := c.Open && c.MDC.Started()
+	switch i := indexOf(c); {
+	case open && i < 0:
+		if len(stack) > 0 {
+			stack[len(stack)-1].trap.Pause()
+		}
+		stack = append(stack, c)
+		hideOthers()
+	case !open && i >= 0:
+		c.removeFromStack()
+	}
+}
+
+// removeFromStack removes c from the stack of open dialogs, and lets the
+// dialog that becomes the topmost one trap the focus again.
+func (c *D) removeFromStack() {
+	i := indexOf(c)
+	if i < 0 {
+		return
+	}
+	stack = append(stack[:i], stack[i+1:]...)
+	if len(stack) > 0 {
+		stack[len(stack)-1].trap.Resume()
+	}
+	hideOthers()
+}
+
+func indexOf(c *D) int {
+	for i, d := range stack {
+		if d == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// hideOthers sets aria-hidden on the siblings of the topmost dialog and of its
+// ancestors, after restoring the elements it hid before.
+func hideOthers() {
+	for _, n := range hidden {
+		if n.Call("getAttribute", "aria-hidden").String() == "true" {
+			n.Call("removeAttribute", "aria-hidden")
+		}
+	}
+	hidden = nil
+	if len(stack) == 0 {
+		if escapeListener != nil {
+			js.Global.Call("removeEventListener", "keydown", escapeListener,
+				true)
+			escapeListener = nil
+		}
+		return
+	}
+	if escapeListener == nil {
+		escapeListener = js.MakeFunc(onEscape)
+		js.Global.Call("addEventListener", "keydown", escapeListener, true)
+	}
+
+	body := js.Global.Get("document").Get("body")
+	n := stack[len(stack)-1].MDC.RootElement.Node()
+	for n != body && n.Get("parentElement") != nil {
+		siblings := n.Get("parentElement").Get("children")
+		for i := 0; i < siblings.Length(); i++ {
+			s := siblings.Index(i)
+			if s == n || s.Call("hasAttribute", "aria-hidden").Bool() {
+				continue
+			}
+			s.Call("setAttribute", "aria-hidden", "true")
+			hidden = append(hidden, s)
+		}
+		n = n.Get("parentElement")
+	}
+}
+
+// onEscape runs before MDC sees an escape key press, which cancels every open
+// dialog. See patchCancel.
+func onEscape(this *js.Object, args []*js.Object) interface{} {
+	if args[0].Get("key").String() != "Escape" {
+		return nil
+	}
+	if len(stack) > 0 {
+		escaping = stack[len(stack)-1]
+		js.Global.Call("setTimeout", func() { escaping = nil }, 0)
+	}
+	return nil
+}
+
+// patchCancel makes MDC only cancel the dialog on the escape key or a click on
+// the backdrop if it is the topmost dialog and allows it, and records why the
+// dialog was cancelled.
+func (c *D) patchCancel() {
+	d, ok := c.MDC.Component.(*dialog.D)
+	if !ok {
+		return
+	}
+	f := d.Component().Get("foundation_")
+	if f == js.Undefined {
+		return
+	}
+	cancel := f.Get("cancel")
+	f.Set("cancel", func(notify bool) {
+		reason := CloseCancel
+		switch {
+		case escaping != nil:
+			reason = CloseEscape
+		case c.backdropClicked:
+			reason = CloseBackdrop
+		}
+		c.backdropClicked = false
+		switch reason {
+		case CloseEscape:
+			// The topmost dialog may have left the stack already.
+			if escaping != c || c.NoEscapeClose {
+				return
+			}
+		case CloseBackdrop:
+			if !c.top() || c.NoBackdropClose {
+				return
+			}
+		}
+		c.closeReason = reason
+		cancel.Call("call", f, notify)
+	})
+}
+
+// onBackdropClick marks the clicks MDC cancels the dialog on which are not in
+// its surface.
+func (c *D) onBackdropClick(e *vecty.Event) {
+	t := e.Target
+	c.backdropClicked = t == c.MDC.RootElement.Node() ||
+		t.Get("classList").Call("contains", "mdc-dialog__backdrop").Bool()
+}
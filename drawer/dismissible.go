@@ -0,0 +1,147 @@
+package drawer
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+)
+
+// The classes of the newer MDC drawer, which animate Dismissible and Modal
+// drawers.
+//
+// The MDC drawer class for this markup, MDCDrawer, is not part of the
+// material-components-web 0.28 this package is built against, and only one
+// version of MDC can be loaded in the global mdc. Its foundation is therefore
+// followed here: open adds the open and animate classes, then opening in the
+// next frame; close adds closing; the transition end of the drawer removes
+// them. Once MDC is upgraded, Dismissible and Modal drawers should wrap
+// mdc.drawer.MDCDrawer with base.Adapter instead, like the other types wrap
+// their MDC classes.
+const (
+	openClass    = "mdc-drawer--open"
+	animateClass = "mdc-drawer--animate"
+	openingClass = "mdc-drawer--opening"
+	closingClass = "mdc-drawer--closing"
+)
+
+// AppContent returns the element holding the content of the page next to a
+// Dismissible drawer, which makes room for the drawer while it is open. It has
+// to be rendered right after the drawer.
+func AppContent(markup ...vecty.MarkupOrChild) *vecty.HTML {
+	return elem.Div(append([]vecty.MarkupOrChild{
+		vecty.Markup(vecty.Class("mdc-drawer-app-content")),
+	}, markup...)...)
+}
+
+// isOverlay reports whether the drawer covers the page while it is open.
+func (c *D) isOverlay() bool {
+	return c.Type == Temporary || c.Type == Modal
+}
+
+func (c *D) variant() bool {
+	return c.Type == Dismissible || c.Type == Modal
+}
+
+// renderVariant renders a Dismissible or Modal drawer. The scrim of a Modal
+// drawer follows it.
+func (c *D) renderVariant(markup vecty.MarkupList) vecty.ComponentOrHTML {
+	var header vecty.ComponentOrHTML
+	if c.Header != nil {
+		header = elem.Div(
+			vecty.Markup(vecty.Class("mdc-drawer__header")),
+			slot(c.Header),
+		)
+	}
+	var content vecty.ComponentOrHTML
+	if c.Content != nil {
+		content = elem.Div(
+			vecty.Markup(vecty.Class("mdc-drawer__content")),
+			slot(c.Content),
+		)
+	}
+	drawer := elem.Aside(
+		markup,
+		header,
+		content,
+		c.renderFooter(),
+	)
+	if c.Type != Modal {
+		return drawer
+	}
+	return elem.Div(
+		vecty.Markup(vecty.Style("display", "contents")),
+		drawer,
+		elem.Div(
+			vecty.Markup(
+				vecty.Class("mdc-drawer-scrim"),
+				event.Click(func(e *vecty.Event) { c.closeVariant() }),
+			),
+		),
+	)
+}
+
+func (c *D) classList() *js.Object {
+	return c.MDC.RootElement.Node().Get("classList")
+}
+
+// reconcileVariant opens or closes a mounted Dismissible or Modal drawer with
+// its animation.
+func (c *D) reconcileVariant() {
+	if !c.variant() {
+		return
+	}
+	c.reconcileFocus()
+	switch open := c.classList().Call("contains", openClass).Bool(); {
+	case c.Open && !open:
+		c.openVariant()
+	case !c.Open && open:
+		c.closeVariant()
+	}
+}
+
+func (c *D) openVariant() {
+	cl := c.classList()
+	if cl.Call("contains", openClass).Bool() ||
+		cl.Call("contains", closingClass).Bool() {
+		return
+	}
+	cl.Call("add", openClass, animateClass)
+	js.Global.Call("requestAnimationFrame", func() {
+		cl.Call("add", openingClass)
+	})
+}
+
+func (c *D) closeVariant() {
+	cl := c.classList()
+	if !cl.Call("contains", openClass).Bool() ||
+		cl.Call("contains", closingClass).Bool() {
+		return
+	}
+	cl.Call("add", closingClass)
+}
+
+// onTransitionEnd ends the animation of the drawer, and reports that it opened
+// or closed.
+func (c *D) onTransitionEnd(e *vecty.Event) {
+	if e.Target != c.MDC.RootElement.Node() {
+		return
+	}
+	cl := c.classList()
+	closing := cl.Call("contains", closingClass).Bool()
+	if closing {
+		cl.Call("remove", openClass)
+	}
+	cl.Call("remove", animateClass, openingClass, closingClass)
+	if closing {
+		c.onClose(e)
+		return
+	}
+	c.onOpen(e)
+}
+
+func (c *D) onKeyDown(e *vecty.Event) {
+	if e.Get("key").String() == "Escape" {
+		c.closeVariant()
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"agamigo.io/material/persistentdrawer"
 	"agamigo.io/material/temporarydrawer"
 	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/base/mdcevent"
+	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 )
@@ -14,6 +16,13 @@ const (
 	Temporary Type = iota
 	Persistent
 	Permanent
+
+	// Dismissible and Modal drawers use the markup of the newer MDC drawer,
+	// whose styles the app has to include. A Dismissible drawer pushes the
+	// AppContent rendered after it aside, a Modal drawer covers the page with
+	// a scrim and traps the focus.
+	Dismissible
+	Modal
 )
 
 // D is a vecty-material drawer component.
@@ -28,6 +37,37 @@ type D struct {
 	Header        vecty.ComponentOrHTML
 	ToolbarSpacer vecty.ComponentOrHTML
 	Content       vecty.ComponentOrHTML
+
+	// Footer is shown at the bottom of the drawer, below Content.
+	Footer vecty.ComponentOrHTML
+
+	// InitialFocus is the element focused when a temporary drawer opens. By
+	// default it is the first focusable element of the drawer.
+	InitialFocus *vecty.HTML
+
+	// OnOpen and OnClose are called when a temporary or persistent drawer has
+	// opened or closed, e.g. when the user clicks the scrim of a temporary
+	// drawer or swipes it closed.
+	OnOpen  func(this *D, e *mdcevent.DrawerOpen)
+	OnClose func(this *D, e *mdcevent.DrawerClose)
+
+	// Breakpoints make the drawer responsive. The drawer takes the Type of the
+	// breakpoint with the largest MinWidth the viewport is at least as wide
+	// as, or Temporary if there is none. A drawer becoming Temporary or Modal
+	// closes.
+	// Switching between the Temporary, Persistent and Permanent types keeps
+	// the slots of the drawer mounted, while Dismissible and Modal drawers
+	// have other markup, so that their slots are mounted again.
+	Breakpoints []Breakpoint
+
+	// Controlled makes Open the source of truth for the drawer's visibility.
+	// Closing the drawer only calls OnClose, which should update Open and
+	// rerender. See base.SyncState.
+	Controlled bool
+
+	trap     base.FocusTrap
+	watched  []breakpointListener
+	rendered Type
 }
 
 // Render implements the vecty.Component interface.
@@ -35,7 +75,7 @@ func (c *D) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("div", c.Root)
 	}
 
 	markup := vecty.Markup(
@@ -43,43 +83,64 @@ func (c *D) Render() vecty.ComponentOrHTML {
 		vecty.MarkupIf(rootMarkup != nil, *rootMarkup),
 	)
 
-	// Built-in root element.
-	if c.Type == Permanent {
+	responsive := len(c.Breakpoints) > 0
+	if responsive {
+		c.reconcileBreakpoints()
+	}
+
+	// Built-in root element. A responsive drawer switching to or from the
+	// markup of Dismissible and Modal drawers remounts its slots.
+	if c.variant() {
+		return c.renderVariant(markup)
+	}
+	if c.Type == Permanent && !responsive {
 		return elem.Navigation(
 			markup,
 			c.renderDrawer(),
 		)
 	}
-	// Persistent or Temporary drawer.
+	// Persistent or Temporary drawer. A responsive drawer keeps this structure
+	// as a Permanent drawer, so that its content is not rendered again when it
+	// changes type.
 	return elem.Aside(
 		markup,
 		elem.Navigation(
-			vecty.Markup(vecty.Class("mdc-drawer__drawer")),
+			vecty.Markup(
+				vecty.Class("mdc-drawer__drawer"),
+				vecty.MarkupIf(c.Type == Permanent,
+					vecty.Style("display", "contents")),
+			),
 			c.renderDrawer(),
 		),
 	)
 }
 
 func (c *D) Apply(h *vecty.HTML) {
+	switched := c.MDC != nil && c.MDC.RootElement != nil &&
+		c.rendered != c.Type
+	c.rendered = c.Type
 	switch {
 	case c.MDC == nil:
 		c.MDC = &base.MDC{}
 		fallthrough
 	case c.MDC.Component == nil:
-		switch c.Type {
-		case Permanent:
-		case Temporary:
-			c.MDC.Component = temporarydrawer.New()
-			c.MDC.Component.(*temporarydrawer.TD).Open = c.Open
-		case Persistent:
-			c.MDC.Component = persistentdrawer.New()
-			c.MDC.Component.(*persistentdrawer.PD).Open = c.Open
+		c.newComponent()
+		switch {
+		case switched:
+			c.startLater()
+		case c.MDC.RootElement != nil:
+			// A mounted drawer without MDC component rerenders.
+			c.reconcileVariant()
 		}
+	default:
+		c.reconcileType()
+		c.reconcile()
 	}
 
 	markup := []vecty.Applyer{
 		vecty.Class("mdc-drawer"),
-		vecty.MarkupIf(c.Open, vecty.Class("mdc-drawer--open")),
+		// Dismissible and Modal drawers animate their open class themselves.
+		vecty.MarkupIf(c.Open && !c.variant(), vecty.Class(openClass)),
 	}
 	switch c.Type {
 	case Permanent:
@@ -88,46 +149,282 @@ func (c *D) Apply(h *vecty.HTML) {
 		markup = append(markup, vecty.Class("mdc-drawer--temporary"))
 	case Persistent:
 		markup = append(markup, vecty.Class("mdc-drawer--persistent"))
+	case Dismissible:
+		markup = append(markup, vecty.Class("mdc-drawer--dismissible"))
+	case Modal:
+		markup = append(markup, vecty.Class("mdc-drawer--modal"))
+	}
+
+	switch c.Type {
+	case Temporary:
+		markup = append(markup,
+			&vecty.EventListener{
+				Name:     mdcevent.TemporaryDrawerOpenName,
+				Listener: c.onOpen,
+			},
+			&vecty.EventListener{
+				Name:     mdcevent.TemporaryDrawerCloseName,
+				Listener: c.onClose,
+			},
+		)
+	case Persistent:
+		markup = append(markup,
+			&vecty.EventListener{
+				Name:     mdcevent.PersistentDrawerOpenName,
+				Listener: c.onOpen,
+			},
+			&vecty.EventListener{
+				Name:     mdcevent.PersistentDrawerCloseName,
+				Listener: c.onClose,
+			},
+		)
+	case Dismissible, Modal:
+		markup = append(markup, &vecty.EventListener{
+			Name:     "transitionend",
+			Listener: c.onTransitionEnd,
+		})
+		if c.Type == Modal {
+			markup = append(markup, &vecty.EventListener{
+				Name:     "keydown",
+				Listener: c.onKeyDown,
+			})
+		}
 	}
 
 	vecty.Markup(markup...).Apply(h)
 	c.MDC.RootElement = h
 }
 
+// Mount implements the vecty.Mounter interface.
+func (c *D) Mount() {
+	c.MDC.Mount()
+	if c.variant() && c.Open {
+		c.classList().Call("add", openClass)
+	}
+	c.reconcileFocus()
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *D) Unmount() {
+	c.trap.Deactivate()
+	c.unwatchBreakpoints()
+	c.MDC.Unmount()
+}
+
+// newComponent sets the MDC component of Type, which is not started yet.
+func (c *D) newComponent() {
+	switch c.Type {
+	case Temporary:
+		td := temporarydrawer.New()
+		td.Open = c.Open
+		c.MDC.Component = td
+	case Persistent:
+		pd := persistentdrawer.New()
+		pd.Open = c.Open
+		c.MDC.Component = pd
+	default:
+		c.MDC.Component = nil
+	}
+}
+
+// reconcileType stops the MDC component when Type changed, and starts the
+// one of the new Type once the drawer has been rendered with it.
+func (c *D) reconcileType() {
+	switch c.MDC.Component.(type) {
+	case *temporarydrawer.TD:
+		if c.Type == Temporary {
+			return
+		}
+	case *persistentdrawer.PD:
+		if c.Type == Persistent {
+			return
+		}
+	}
+	c.trap.Deactivate()
+	c.MDC.Unmount()
+	c.newComponent()
+	c.startLater()
+}
+
+// startLater starts the MDC component of a drawer that changed type once it
+// has been rendered with its new markup. A drawer that became Dismissible or
+// Modal shows its state without animation instead, as when it mounts, before
+// the frame without the open class of its previous markup is painted.
+func (c *D) startLater() {
+	mdc := c.MDC.Component
+	if mdc == nil {
+		if !c.variant() {
+			return
+		}
+		js.Global.Get("Promise").Call("resolve").Call("then", func() {
+			if c.variant() && c.MDC.Component == nil {
+				if c.Open {
+					c.classList().Call("add", openClass)
+				}
+				c.reconcileFocus()
+			}
+		})
+		return
+	}
+	js.Global.Call("requestAnimationFrame", func() {
+		if c.MDC.Component == mdc && !c.MDC.Started() {
+			c.MDC.Mount()
+			c.reconcileFocus()
+		}
+	})
+}
+
+// reconcile pushes changes of the Go fields into the running MDC component,
+// which opens or closes the drawer with its animation.
+func (c *D) reconcile() {
+	if !c.MDC.Started() {
+		return
+	}
+	switch t := c.MDC.Component.(type) {
+	case *temporarydrawer.TD:
+		// Trap the focus before MDC moves it, to remember which element had
+		// it.
+		c.reconcileFocus()
+		if t.Open != c.Open {
+			t.Open = c.Open
+		}
+	case *persistentdrawer.PD:
+		if t.Open != c.Open {
+			t.Open = c.Open
+		}
+	}
+}
+
+// reconcileFocus traps the focus inside an open temporary or modal drawer, and
+// restores it to the element that opened the drawer once it is closed.
+func (c *D) reconcileFocus() {
+	_, temporary := c.MDC.Component.(*temporarydrawer.TD)
+	open := c.Open && (temporary && c.MDC.Started() ||
+		c.Type == Modal && c.MDC.RootElement != nil)
+	switch {
+	case open && !c.trap.Active():
+		var initial *js.Object
+		if c.InitialFocus != nil {
+			initial = c.InitialFocus.Node()
+		}
+		c.trap.Activate(c.MDC.RootElement.Node(), initial)
+	case !open && c.trap.Active():
+		c.trap.Deactivate()
+	}
+}
+
+// SetOpen opens or closes the drawer, animating the running MDC component.
+// Before the drawer is rendered, it only sets Open.
+func (c *D) SetOpen(open bool) {
+	c.Open = open
+	if c.MDC == nil || c.MDC.RootElement == nil {
+		return
+	}
+	c.reconcile()
+	vecty.Rerender(c)
+}
+
+// Toggle opens the drawer if it is closed, and closes it if it is open.
+func (c *D) Toggle() {
+	c.SetOpen(!c.Open)
+}
+
+func (c *D) onOpen(e *vecty.Event) {
+	base.SyncState(c, c.Controlled,
+		c.storeOpen,
+		func() {
+			if c.OnOpen != nil {
+				c.OnOpen(c, mdcevent.DecodeDrawerOpen(e))
+			}
+		},
+		c.restoreOpen,
+	)
+}
+
+func (c *D) onClose(e *vecty.Event) {
+	base.SyncState(c, c.Controlled,
+		c.storeOpen,
+		func() {
+			if c.OnClose != nil {
+				c.OnClose(c, mdcevent.DecodeDrawerClose(e))
+			}
+		},
+		c.restoreOpen,
+	)
+	// Release the focus right away when the user closed the drawer.
+	c.reconcileFocus()
+}
+
+func (c *D) storeOpen() {
+	if c.variant() {
+		c.Open = c.classList().Call("contains", openClass).Bool()
+		return
+	}
+	switch t := c.MDC.Component.(type) {
+	case *temporarydrawer.TD:
+		c.Open = t.Open
+	case *persistentdrawer.PD:
+		c.Open = t.Open
+	}
+}
+
+func (c *D) restoreOpen() {
+	if c.variant() {
+		c.reconcileVariant()
+		return
+	}
+	switch t := c.MDC.Component.(type) {
+	case *temporarydrawer.TD:
+		t.Open = c.Open
+	case *persistentdrawer.PD:
+		t.Open = c.Open
+	}
+}
+
 func (c *D) renderDrawer() vecty.List {
-	var elements []vecty.ComponentOrHTML
+	var elements vecty.List
 	if c.ToolbarSpacer != nil {
-		var h *vecty.HTML
-		var ok bool
-		h, ok = c.ToolbarSpacer.(*vecty.HTML)
-		if h != nil && ok {
-			vecty.Class("mdc-drawer__toolbar-spacer").Apply(h)
-			elements = append(elements, elem.Div(
-				vecty.Markup(vecty.Class("mdc-drawer__toolbar-spacer")),
-				vecty.If(ok, h),
-				vecty.If(!ok, c.ToolbarSpacer),
-			))
-		}
+		elements = append(elements, elem.Div(
+			vecty.Markup(vecty.Class("mdc-drawer__toolbar-spacer")),
+			slot(c.ToolbarSpacer),
+		))
 	}
 	if c.Header != nil {
-		var h *vecty.HTML
-		var ok bool
-		h, ok = c.Header.(*vecty.HTML)
-		if h != nil && ok {
-			vecty.Class("mdc-drawer__header-content").Apply(h)
-			elements = append(elements, elem.Header(
-				vecty.Markup(vecty.Class("mdc-drawer__header")),
-				vecty.If(ok, h),
-				vecty.If(!ok, c.Header),
-			))
-			c.Header = base.RenderStoredChild(h)
-		}
+		elements = append(elements, elem.Header(
+			vecty.Markup(vecty.Class("mdc-drawer__header")),
+			elem.Div(
+				vecty.Markup(vecty.Class("mdc-drawer__header-content")),
+				slot(c.Header),
+			),
+		))
 	}
 	if c.Content != nil {
 		elements = append(elements, elem.Navigation(
 			vecty.Markup(vecty.Class("mdc-drawer__content")),
-			c.Content,
+			slot(c.Content),
 		))
 	}
-	return elements
+	return append(elements, c.renderFooter())
+}
+
+func (c *D) renderFooter() vecty.ComponentOrHTML {
+	if c.Footer == nil {
+		return nil
+	}
+	return elem.Footer(
+		vecty.Markup(
+			vecty.Class("vecty-material-drawer__footer"),
+			vecty.Style("flex-shrink", "0"),
+		),
+		slot(c.Footer),
+	)
+}
+
+// slot returns the content of a slot of the drawer. An element is wrapped so
+// that it can be rendered again, see base.RenderStoredChild.
+func slot(child vecty.ComponentOrHTML) vecty.ComponentOrHTML {
+	if h, ok := child.(*vecty.HTML); ok {
+		return base.RenderStoredChild(h)
+	}
+	return child
 }
@@ -0,0 +1,162 @@
+package drawer
+
+import (
+	"strconv"
+	"strings"
+
+	"agamigo.io/vecty-material/icon"
+	"agamigo.io/vecty-material/ul"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+)
+
+// NavItem is a link of a Nav.
+type NavItem struct {
+	Label string
+
+	// Icon is the name of the material icon shown before Label.
+	Icon string
+
+	Href string
+
+	// Children are links shown indented under the item.
+	Children []*NavItem
+}
+
+// NavSection is a list of links of a Nav, with an optional header.
+type NavSection struct {
+	Header string
+	Items  []*NavItem
+}
+
+// Nav is a navigation list rendered as the Content of a drawer. The item whose
+// Href matches the current URL is activated, and a Temporary or Modal Drawer is
+// closed after navigating.
+type Nav struct {
+	vecty.Core
+	Sections []*NavSection
+
+	// Drawer is the drawer whose Content is the Nav.
+	Drawer *D
+
+	// URL is the current URL, for client-side routers. If it is empty, the
+	// location of the document is used, and the Nav is rerendered when it
+	// changes through the history or its hash.
+	URL string
+
+	// OnNavigate is called instead of following the Href of the item the user
+	// clicked, for client-side routers. It should update the URL, or push the
+	// new location in the history.
+	OnNavigate func(n *Nav, item *NavItem, e *vecty.Event)
+
+	group     *ul.Group
+	lists     map[*NavSection]*ul.L
+	items     map[*NavItem]*ul.Item
+	listeners []*js.Object
+}
+
+// Render implements the vecty.Component interface.
+func (c *Nav) Render() vecty.ComponentOrHTML {
+	prevLists, prevItems := c.lists, c.items
+	c.lists = make(map[*NavSection]*ul.L)
+	c.items = make(map[*NavItem]*ul.Item)
+	if c.group == nil {
+		c.group = &ul.Group{}
+	}
+	c.group.Lists = nil
+	for _, s := range c.Sections {
+		l := prevLists[s]
+		if l == nil {
+			l = &ul.L{}
+		}
+		c.lists[s] = l
+		l.GroupSubheader = s.Header
+		l.Items = nil
+		c.appendItems(l, s.Items, 0, prevItems)
+		c.group.Lists = append(c.group.Lists, l)
+	}
+	return c.group
+}
+
+// appendItems appends the list items of items and of their children to l.
+func (c *Nav) appendItems(l *ul.L, items []*NavItem, level int,
+	prev map[*NavItem]*ul.Item) {
+	for _, ni := range items {
+		li := prev[ni]
+		if li == nil {
+			li = &ul.Item{Graphic: &icon.I{}}
+		}
+		c.items[ni] = li
+		active := c.active(ni.Href)
+		li.Href = ni.Href
+		li.Primary = vecty.Text(ni.Label)
+		li.Graphic.(*icon.I).Name = ni.Icon
+		li.Activated = active
+		li.Root = vecty.Markup(
+			vecty.MarkupIf(active, vecty.Attribute("aria-current", "page")),
+			vecty.MarkupIf(level > 0,
+				vecty.Style("padding-left", strconv.Itoa(16+24*level)+"px")),
+		)
+		item := ni
+		li.OnClick = func(_ *ul.Item, e *vecty.Event) {
+			c.navigate(item, e)
+		}
+		l.Items = append(l.Items, li)
+		c.appendItems(l, ni.Children, level+1, prev)
+	}
+}
+
+// Mount implements the vecty.Mounter interface.
+func (c *Nav) Mount() {
+	rerender := js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		if c.URL == "" {
+			vecty.Rerender(c)
+		}
+		return nil
+	})
+	js.Global.Call("addEventListener", "popstate", rerender)
+	js.Global.Call("addEventListener", "hashchange", rerender)
+	c.listeners = []*js.Object{rerender}
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *Nav) Unmount() {
+	for _, l := range c.listeners {
+		js.Global.Call("removeEventListener", "popstate", l)
+		js.Global.Call("removeEventListener", "hashchange", l)
+	}
+	c.listeners = nil
+}
+
+func (c *Nav) navigate(item *NavItem, e *vecty.Event) {
+	if c.OnNavigate != nil {
+		e.Call("preventDefault")
+		c.OnNavigate(c, item, e)
+	}
+	if c.Drawer != nil && c.Drawer.isOverlay() && c.Drawer.Open {
+		c.Drawer.SetOpen(false)
+	}
+	vecty.Rerender(c)
+}
+
+// active reports whether href links to the current URL. The hash of the URL
+// is only compared if href has one.
+func (c *Nav) active(href string) bool {
+	if href == "" {
+		return false
+	}
+	loc := js.Global.Get("location").Get("href").String()
+	current := c.URL
+	if current == "" {
+		current = loc
+	}
+	u := js.Global.Get("URL")
+	target := u.New(href, loc)
+	cur := u.New(current, loc)
+	if target.Get("origin").String() != cur.Get("origin").String() ||
+		target.Get("pathname").String() != cur.Get("pathname").String() {
+		return false
+	}
+	return !strings.Contains(href, "#") ||
+		target.Get("hash").String() == cur.Get("hash").String()
+}
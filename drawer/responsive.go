@@ -0,0 +1,70 @@
+package drawer
+
+import (
+	"strconv"
+
+	"agamigo.io/vecty-material/base"
+	"github.com/gopherjs/vecty"
+)
+
+// Breakpoint is the Type of a responsive drawer on viewports at least
+// MinWidth pixels wide. See D.Breakpoints.
+type Breakpoint struct {
+	MinWidth int
+	Type     Type
+}
+
+// breakpointListener watches the media query of a Breakpoint.
+type breakpointListener struct {
+	Breakpoint
+	mq *base.MediaQuery
+}
+
+// reconcileBreakpoints listens to the media queries of Breakpoints and sets
+// Type to the one of the viewport.
+func (c *D) reconcileBreakpoints() {
+	if !c.watching() {
+		c.unwatchBreakpoints()
+		for _, bp := range c.Breakpoints {
+			l := breakpointListener{Breakpoint: bp}
+			l.mq = base.WatchMediaQuery(
+				"(min-width: "+strconv.Itoa(bp.MinWidth)+"px)",
+				func(bool) { vecty.Rerender(c) })
+			c.watched = append(c.watched, l)
+		}
+	}
+
+	t, width := Temporary, -1
+	for _, l := range c.watched {
+		if l.MinWidth > width && l.mq.Matches() {
+			t, width = l.Type, l.MinWidth
+		}
+	}
+	if t == c.Type {
+		return
+	}
+	c.Type = t
+	if c.isOverlay() {
+		c.Open = false
+	}
+}
+
+// watching reports whether the listeners match Breakpoints.
+func (c *D) watching() bool {
+	if len(c.watched) != len(c.Breakpoints) {
+		return false
+	}
+	for i, l := range c.watched {
+		if l.Breakpoint != c.Breakpoints[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *D) unwatchBreakpoints() {
+	for _, l := range c.watched {
+		l.mq.Stop()
+	}
+	c.watched = nil
+}
@@ -24,7 +24,7 @@ func (c *FF) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("div", c.Root)
 	}
 
 	inputID := applyer.FindID(c.Input)
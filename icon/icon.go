@@ -26,7 +26,7 @@ func (c *I) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("i", c.Root)
 	}
 
 	_, isIconCode := c.iconDetails()
@@ -1,9 +1,11 @@
 package icontoggle
 
 import (
-	"agamigo.io/material/icontoggle"
 	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/base/mdcevent"
 	"agamigo.io/vecty-material/icon"
+	"agamigo.io/vecty-material/ripple"
+	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 )
@@ -13,14 +15,25 @@ type IT struct {
 	*base.MDC
 	vecty.Core
 	Root          vecty.MarkupOrChild
-	ChangeHandler func(thisIT *IT, e *vecty.Event)
-	On            bool
-	Disabled      bool
+	ChangeHandler func(thisIT *IT, e *mdcevent.IconToggleChange)
+	On            bool `js:"on"`
+	Disabled      bool `js:"disabled"`
 	OnIcon        *icon.I
 	OffIcon       *icon.I
 	activeIcon    *icon.I
 	OnLabel       string
 	OffLabel      string
+
+	// The MDC icon toggle has a ripple of its own. If Ripple is set, it
+	// replaces that ripple, e.g. to call Activate, Deactivate and Layout.
+	// NoRipple removes the ripple.
+	Ripple   *ripple.R
+	NoRipple bool
+
+	// Controlled makes On the source of truth for the toggle state. User
+	// interaction only calls ChangeHandler, which should update the field and
+	// rerender. See base.SyncState.
+	Controlled bool
 }
 
 // Render implements the vecty.Component interface.
@@ -28,7 +41,7 @@ func (c *IT) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("span", c.Root)
 	}
 
 	if c.OffIcon == nil || c.OnIcon == nil {
@@ -52,7 +65,15 @@ func (c *IT) Apply(h *vecty.HTML) {
 		c.MDC = &base.MDC{}
 		fallthrough
 	case c.MDC.Component == nil:
-		c.MDC.Component = icontoggle.New()
+		c.MDC.Component = &base.Adapter{
+			Class: "mdc.iconToggle.MDCIconToggle",
+			Props: c,
+			Events: map[string]func(*vecty.Event){
+				mdcevent.IconToggleChangeName: c.onChange,
+			},
+		}
+	default:
+		c.reconcile()
 	}
 
 	var markup []vecty.Applyer
@@ -97,29 +118,52 @@ func (c *IT) Apply(h *vecty.HTML) {
 			vecty.Class("mdc-icon-toggle--disabled"),
 			vecty.Attribute("aria-hidden", true),
 		),
-		&vecty.EventListener{
-			Name: "MDCIconToggle:change",
-			Listener: func(e *vecty.Event) {
-				c.On = !c.On
-				vecty.Rerender(c)
-			},
-		},
-		vecty.MarkupIf(c.ChangeHandler != nil,
-			&vecty.EventListener{
-				Name:     "MDCIconToggle:change",
-				Listener: c.wrapChangeHandler(),
-			},
-		),
 		vecty.Markup(markup...),
 		vecty.MarkupIf(c.On,
 			vecty.Class("mdc-icon-toggle--on"),
 		),
 	).Apply(h)
+	if c.Ripple != nil && !c.NoRipple {
+		c.Ripple.Apply(h)
+	}
 	c.MDC.RootElement = h
 }
 
-func (c *IT) wrapChangeHandler() func(e *vecty.Event) {
-	return func(e *vecty.Event) {
-		c.ChangeHandler(c, e)
+// Mount implements the vecty.Mounter interface.
+func (c *IT) Mount() {
+	c.MDC.Mount()
+	if !c.NoRipple && c.Ripple == nil || !c.MDC.Started() {
+		return
+	}
+	// Keep a single ripple on the root element. Destroying the ripple of the
+	// MDC component resets the classes of the element, so Ripple starts after.
+	if r := c.MDC.Component.Component().Get("ripple_"); r != js.Undefined {
+		r.Call("destroy")
 	}
+	if c.Ripple != nil && !c.NoRipple {
+		c.Ripple.Stop()
+		c.Ripple.Start()
+	}
+}
+
+// reconcile pushes changes of the Go fields into the running MDC component.
+func (c *IT) reconcile() {
+	if a, ok := c.MDC.Component.(*base.Adapter); ok {
+		a.Push()
+	}
+}
+
+func (c *IT) onChange(e *vecty.Event) {
+	ce := mdcevent.DecodeIconToggleChange(e)
+	base.SyncState(c, c.Controlled,
+		func() {
+			c.On = ce.IsOn
+		},
+		func() {
+			if c.ChangeHandler != nil {
+				c.ChangeHandler(c, ce)
+			}
+		},
+		c.reconcile,
+	)
 }
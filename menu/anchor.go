@@ -0,0 +1,92 @@
+package menu
+
+import (
+	"strconv"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+func (c *M) fixed() bool {
+	return c.Fixed || c.Overlay || c.AnchorRef != nil || c.ContextTarget != nil
+}
+
+// reconcileAnchor pushes Corner and Margins into the running MDC component.
+func (c *M) reconcileAnchor() {
+	if c.M.AnchorCorner() != c.Corner {
+		c.M.SetAnchorCorner(c.Corner)
+	}
+	if m := c.Margins; *c.M.AnchorMargins() != m {
+		c.M.SetAnchorMargins(&m)
+	}
+}
+
+// patchAdapter makes the MDC menu measure AnchorRef, or the point a context
+// menu was opened at, instead of its parent element, and converts the position
+// it computes relative to the anchor into viewport coordinates when the menu is
+// fixed.
+func (c *M) patchAdapter() {
+	f := c.M.Component().Get("foundation_")
+	if f == js.Undefined {
+		return
+	}
+	a := f.Get("adapter_")
+	hasAnchor := a.Get("hasAnchor")
+	getAnchorDimensions := a.Get("getAnchorDimensions")
+	setPosition := a.Get("setPosition")
+
+	anchorRect := func() *js.Object {
+		switch {
+		case c.ContextTarget != nil && c.contextPoint != nil:
+			return c.contextPoint
+		case c.AnchorRef != nil:
+			return c.AnchorRef.Node().Call("getBoundingClientRect")
+		case c.Overlay:
+			return c.overlayAnchor().Call("getBoundingClientRect")
+		}
+		return getAnchorDimensions.Invoke()
+	}
+	a.Set("hasAnchor", func() bool {
+		return c.AnchorRef != nil || c.Overlay ||
+			c.ContextTarget != nil && c.contextPoint != nil ||
+			hasAnchor.Invoke().Bool()
+	})
+	a.Set("getAnchorDimensions", anchorRect)
+	a.Set("setPosition", func(position *js.Object) {
+		if c.fixed() {
+			position = viewportPosition(position, anchorRect())
+		}
+		setPosition.Invoke(position)
+	})
+}
+
+// overlayAnchor returns the element a menu in the overlay layer is anchored to
+// when AnchorRef is not set: its AnchorElement wrapper, or the element the menu
+// is rendered in.
+func (c *M) overlayAnchor() *js.Object {
+	if c.menuAnchor != nil && c.AnchorElement != nil {
+		return c.menuAnchor.Node()
+	}
+	return c.portal.Placeholder().Get("parentElement")
+}
+
+// viewportPosition converts the CSS position of a menu relative to the anchor
+// rectangle rect into a position relative to the viewport.
+func viewportPosition(position, rect *js.Object) *js.Object {
+	doc := js.Global.Get("document").Get("documentElement")
+	offsets := map[string]float64{
+		"left":   rect.Get("left").Float(),
+		"top":    rect.Get("top").Float(),
+		"right":  doc.Get("clientWidth").Float() - rect.Get("right").Float(),
+		"bottom": doc.Get("clientHeight").Float() - rect.Get("bottom").Float(),
+	}
+	p := js.Global.Get("Object").New()
+	for side, offset := range offsets {
+		v := position.Get(side)
+		if v == js.Undefined || v == nil {
+			continue
+		}
+		px := js.Global.Call("parseFloat", v).Float() + offset
+		p.Set(side, strconv.FormatFloat(px, 'f', -1, 64)+"px")
+	}
+	return p
+}
@@ -0,0 +1,256 @@
+package menu
+
+import (
+	"math"
+
+	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/base/mdcevent"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+)
+
+const (
+	// longPressDelay is the time in milliseconds a touch is held to open a
+	// context menu.
+	longPressDelay = 500
+
+	// longPressSlop is the distance in pixels a touch can move during a long
+	// press.
+	longPressSlop = 10
+
+	// contextMenuDebounce is the time in milliseconds during which the
+	// contextmenu event that browsers may fire after a long press or a key
+	// press that already opened the menu is ignored.
+	contextMenuDebounce = 600
+)
+
+// listener is a DOM event listener that can be removed.
+type listener struct {
+	target  *js.Object
+	name    string
+	fn      *js.Object
+	capture bool
+}
+
+func addListener(target *js.Object, name string, capture bool,
+	fn func(e *js.Object)) listener {
+	l := listener{
+		target:  target,
+		name:    name,
+		capture: capture,
+		fn: js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+			fn(args[0])
+			return nil
+		}),
+	}
+	target.Call("addEventListener", name, l.fn, capture)
+	return l
+}
+
+func (l listener) remove() {
+	l.target.Call("removeEventListener", l.name, l.fn, l.capture)
+}
+
+// reconcileContext listens to the events of the element of ContextTarget, and
+// to the events of the document that close a context menu.
+func (c *M) reconcileContext() {
+	var node *js.Object
+	if c.ContextTarget != nil {
+		node = nodeOf(c.ContextTarget)
+		if node == nil {
+			// Not rendered yet.
+			return
+		}
+	}
+	if node == c.contextNode {
+		return
+	}
+	c.unlistenContext()
+	if node == nil {
+		return
+	}
+	c.contextNode = node
+	doc := js.Global.Get("document")
+	c.contextListeners = []listener{
+		addListener(node, "contextmenu", false, c.onContextMenu),
+		addListener(node, "keydown", false, c.onContextKeyDown),
+		addListener(node, "pointerdown", false, c.onLongPressStart),
+		addListener(node, "pointermove", false, c.onLongPressMove),
+		addListener(node, "pointerup", false, c.cancelLongPress),
+		addListener(node, "pointercancel", false, c.cancelLongPress),
+		addListener(doc, "pointerdown", true, c.onDocumentPointerDown),
+		addListener(js.Global, "scroll", true, c.onScroll),
+	}
+}
+
+func (c *M) unlistenContext() {
+	c.cancelLongPress(nil)
+	for _, l := range c.contextListeners {
+		l.remove()
+	}
+	c.contextListeners = nil
+	c.contextNode = nil
+}
+
+func (c *M) onContextMenu(e *js.Object) {
+	e.Call("preventDefault")
+	c.cancelLongPress(nil)
+	if now() < c.suppressUntil {
+		return
+	}
+	x, y := e.Get("clientX").Float(), e.Get("clientY").Float()
+	if x == 0 && y == 0 {
+		// Some browsers report keyboard invocations without a position.
+		c.openAtFocus(e)
+		return
+	}
+	c.openContext(e, x, y, false)
+}
+
+func (c *M) onContextKeyDown(e *js.Object) {
+	key := e.Get("key").String()
+	if key != "ContextMenu" && !(key == "F10" && e.Get("shiftKey").Bool()) {
+		return
+	}
+	e.Call("preventDefault")
+	c.suppressUntil = now() + contextMenuDebounce
+	c.openAtFocus(e)
+}
+
+func (c *M) onLongPressStart(e *js.Object) {
+	if e.Get("pointerType").String() != "touch" {
+		return
+	}
+	c.cancelLongPress(nil)
+	c.longPressX = e.Get("clientX").Float()
+	c.longPressY = e.Get("clientY").Float()
+	c.longPress = js.Global.Call("setTimeout", func() {
+		c.longPress = 0
+		c.suppressUntil = now() + contextMenuDebounce
+		c.openContext(e, c.longPressX, c.longPressY, false)
+	}, longPressDelay).Int()
+}
+
+func (c *M) onLongPressMove(e *js.Object) {
+	if c.longPress == 0 {
+		return
+	}
+	dx := e.Get("clientX").Float() - c.longPressX
+	dy := e.Get("clientY").Float() - c.longPressY
+	if math.Hypot(dx, dy) > longPressSlop {
+		c.cancelLongPress(e)
+	}
+}
+
+func (c *M) cancelLongPress(e *js.Object) {
+	if c.longPress != 0 {
+		js.Global.Call("clearTimeout", c.longPress)
+		c.longPress = 0
+	}
+}
+
+func (c *M) onDocumentPointerDown(e *js.Object) {
+	if !c.contains(e.Get("target")) {
+		c.dismissContext(e)
+	}
+}
+
+func (c *M) onScroll(e *js.Object) {
+	if !c.contains(e.Get("target")) {
+		c.dismissContext(e)
+	}
+}
+
+// openAtFocus opens the menu below the focused element of ContextTarget, with
+// its first item focused.
+func (c *M) openAtFocus(e *js.Object) {
+	el := js.Global.Get("document").Get("activeElement")
+	if el == nil || !c.contextNode.Call("contains", el).Bool() {
+		el = c.contextNode
+	}
+	r := el.Call("getBoundingClientRect")
+	c.openContext(e, r.Get("left").Float(), r.Get("bottom").Float(), true)
+}
+
+// openContext opens the menu at the viewport point (x, y), clamped to the
+// viewport. If focus is set, the first item is focused.
+func (c *M) openContext(e *js.Object, x, y float64, focus bool) {
+	doc := js.Global.Get("document").Get("documentElement")
+	x = math.Max(0, math.Min(x, doc.Get("clientWidth").Float()))
+	y = math.Max(0, math.Min(y, doc.Get("clientHeight").Float()))
+	p := js.Global.Get("Object").New()
+	p.Set("left", x)
+	p.Set("right", x)
+	p.Set("top", y)
+	p.Set("bottom", y)
+	p.Set("width", 0)
+	p.Set("height", 0)
+	c.contextPoint = p
+	c.contextFocus = focus
+	if c.M != nil && c.M.Open {
+		// Close the menu, so that it opens again at the new point.
+		c.closeSubmenus()
+		c.M.Open = false
+	}
+	base.SyncState(c, c.Controlled,
+		func() { c.Open = true },
+		func() {
+			if c.OnContextMenu != nil {
+				c.OnContextMenu(&vecty.Event{Object: e, Target: e.Get("target")})
+			}
+		},
+		func() { vecty.Rerender(c) },
+	)
+}
+
+// dismissContext closes the open menu as if it was cancelled.
+func (c *M) dismissContext(e *js.Object) {
+	if c.M == nil || !c.M.Open {
+		return
+	}
+	c.closeSubmenus()
+	// Close the MDC menu right away, so that it does not also cancel itself on
+	// the click that follows.
+	c.M.Open = false
+	base.SyncState(c, c.Controlled,
+		func() { c.Open = false },
+		func() {
+			if c.OnCancel != nil {
+				c.OnCancel(mdcevent.DecodeMenuCancel(
+					&vecty.Event{Object: e, Target: e.Get("target")}))
+			}
+		},
+		c.restoreOpen,
+	)
+}
+
+// contains reports whether node is inside the menu or one of its submenus.
+func (c *M) contains(node *js.Object) bool {
+	if c.M == nil {
+		return false
+	}
+	root := c.M.Component().RootElement
+	if root != nil && (root == node || root.Call("contains", node).Bool()) {
+		return true
+	}
+	for _, sub := range c.submenus {
+		if sub.contains(node) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeOf returns the DOM node of h, or nil if it has not been created yet.
+func nodeOf(h *vecty.HTML) (node *js.Object) {
+	defer func() {
+		if recover() != nil {
+			node = nil
+		}
+	}()
+	return h.Node()
+}
+
+func now() float64 {
+	return js.Global.Get("Date").Call("now").Float()
+}
@@ -1,8 +1,11 @@
 package menu
 
 import (
+	"strconv"
+
 	"agamigo.io/material/menu"
 	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/base/mdcevent"
 	"agamigo.io/vecty-material/ul"
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
@@ -31,13 +34,80 @@ type M struct {
 	// which the element will be anchored.
 	AnchorElement vecty.ComponentOrHTML
 
-	// Define OnSelect to handle "MDCMenu:selected" events. item is the
-	// menu item that was selected.
-	OnSelect func(index int, item vecty.ComponentOrHTML, e *vecty.Event)
+	// AnchorRef anchors the menu to an element elsewhere in the page, such as
+	// the RootElement of a component, instead of AnchorElement. The menu is
+	// Fixed while AnchorRef is set.
+	AnchorRef *vecty.HTML
+
+	// Corner is the corner of the anchor the menu is attached to. It is named
+	// so as not to hide the AnchorCorner method of the MDC menu.
+	Corner menu.Corner
+
+	// Margins are the distances between the menu and Corner.
+	Margins menu.Margins
+
+	// Fixed positions the menu relative to the viewport, so that it is not
+	// clipped by an ancestor of its anchor that hides its overflow.
+	Fixed bool
+
+	// Overlay renders the menu in the overlay layer at the end of the document
+	// body instead of in place, anchored to AnchorRef, AnchorElement or the
+	// element the menu is rendered in. The menu is Fixed while Overlay is set.
+	// See base.Portal.
+	Overlay bool
+
+	// If FocusItem is set, the item at FocusIndex in List is focused when the
+	// menu is opened, instead of the menu itself.
+	FocusItem  bool
+	FocusIndex int
+
+	// ContextTarget makes the menu a context menu of an element. The menu
+	// opens at the pointer when the element's context menu is requested with a
+	// right click or a long press, or below the focused element with Shift+F10
+	// or the Menu key. It closes on scroll and on clicks outside of it.
+	ContextTarget *vecty.HTML
+
+	// OnContextMenu is called when the context menu of ContextTarget is
+	// requested, before the menu opens. A Controlled menu only opens if it sets
+	// Open.
+	OnContextMenu func(e *vecty.Event)
+
+	// Define OnSelect to handle "MDCMenu:selected" events. item is the menu
+	// item that was selected, and path holds the position of the item chosen
+	// in each menu of the cascade, starting with List. For a selection in List
+	// itself, path only holds the position of item in List. If List is a
+	// *vecty.HTML, item is nil and path holds the index counted by MDC.
+	//
+	// A *ul.Item of List with Children opens a submenu listing them, on hover,
+	// click, Enter or the right arrow key. A submenu closes with the left arrow
+	// or escape key, and selecting one of its items closes the whole cascade.
+	OnSelect func(path []int, item vecty.ComponentOrHTML,
+		e *mdcevent.MenuSelected)
+
+	// Define OnCancel to handle "MDCMenu:cancel" events.
+	OnCancel func(e *mdcevent.MenuCancel)
 
-	// Define OnCancel to handle "MDCMenu:selected" events. item is the
-	// menu item that was selected.
-	OnCancel func(e *vecty.Event)
+	// Controlled makes Open the source of truth for the menu's visibility.
+	// Selecting an item or cancelling the menu only calls OnSelect/OnCancel,
+	// which should update Open and rerender. See base.SyncState.
+	Controlled bool
+
+	parent      *M
+	parentItem  *ul.Item
+	parentIndex int
+	submenus    map[*ul.Item]*M
+	roots       map[interface{}]injectedRoot
+	portal      *base.Portal
+	listeners   []listener
+
+	contextNode      *js.Object
+	contextPoint     *js.Object
+	contextFocus     bool
+	contextListeners []listener
+	longPress        int
+	longPressX       float64
+	longPressY       float64
+	suppressUntil    float64
 }
 
 // Render implements the vecty.Component interface.
@@ -45,7 +115,7 @@ func (c *M) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("div", c.Root)
 	}
 
 	// TODO: Make initial values work in material package
@@ -58,24 +128,48 @@ func (c *M) Render() vecty.ComponentOrHTML {
 	if !open {
 		listMarkup = append(listMarkup, vecty.Attribute("aria-hidden", "true"))
 	}
-	switch t := c.List.(type) {
-	case *ul.L:
-		if mu := base.MarkupOnly(t.Root); mu != nil {
-			listMarkup = append(listMarkup, mu)
+	// The menu's markup replaces the Root of the list and its items on each
+	// render, the Root the user gave them is kept in c.roots until the user
+	// assigns another one.
+	prevRoots := c.roots
+	c.roots = make(map[interface{}]injectedRoot)
+	inject := func(owner interface{}, root *vecty.MarkupOrChild,
+		markup ...vecty.Applyer) {
+		user := *root
+		if r, ok := prevRoots[owner]; ok {
+			if p, ok := user.(*vecty.MarkupList); ok && p == r.markup {
+				user = r.user
+			}
 		}
-		t.Root = vecty.Markup(listMarkup...)
-		for _, item := range t.Items {
-			if i, ok := item.(*ul.Item); ok {
-				itemMarkup := []vecty.Applyer{
+		if mu := base.MarkupOnly(user); mu != nil {
+			markup = append(markup, mu)
+		}
+		m := vecty.Markup(markup...)
+		c.roots[owner] = injectedRoot{user: user, markup: &m}
+		*root = &m
+	}
+	markItems := func(items []vecty.ComponentOrHTML) {
+		for i, li := range items {
+			switch t := li.(type) {
+			case *ul.Item:
+				inject(t, &t.Root,
 					vecty.Attribute("role", "menuitem"),
 					vecty.Attribute("tabindex", 0),
-				}
-				if mu := base.MarkupOnly(i.Root); mu != nil {
-					itemMarkup = append(itemMarkup, mu)
-				}
-				i.Root = vecty.Markup(itemMarkup...)
+					vecty.MarkupIf(len(t.Children) > 0,
+						vecty.Attribute("aria-haspopup", "true")),
+					itemIndexData(i),
+				)
+			case *vecty.HTML:
+				itemIndexData(i).Apply(t)
 			}
 		}
+	}
+	switch t := c.List.(type) {
+	case *ul.L:
+		inject(t, &t.Root, listMarkup...)
+		markItems(t.Items)
+	case vecty.List:
+		markItems(t)
 	case *vecty.HTML:
 		vecty.Class("mdc-menu__items").Apply(t)
 		vecty.Attribute("role", "menu").Apply(t)
@@ -84,24 +178,44 @@ func (c *M) Render() vecty.ComponentOrHTML {
 		}
 	}
 
-	menuElement := elem.Div(
+	var menuElement vecty.ComponentOrHTML = elem.Div(
 		vecty.Markup(
 			c,
 			vecty.MarkupIf(rootMarkup != nil, *rootMarkup),
 		),
 		c.List,
 	)
+	if c.Overlay {
+		if c.portal == nil {
+			c.portal = &base.Portal{}
+		}
+		c.portal.Child = menuElement
+		menuElement = c.portal
+	}
 
+	submenus := c.renderSubmenus()
 	if c.AnchorElement != nil {
 		c.menuAnchor = elem.Div(
-			vecty.Markup(
-				vecty.Class("mdc-menu-anchor"),
-			),
-			c.AnchorElement,
-			menuElement,
+			append([]vecty.MarkupOrChild{
+				vecty.Markup(
+					vecty.Class("mdc-menu-anchor"),
+				),
+				c.AnchorElement,
+				menuElement,
+			}, submenus...)...,
 		)
 		return c.menuAnchor
 	}
+	if len(submenus) > 0 {
+		return elem.Div(
+			append([]vecty.MarkupOrChild{
+				vecty.Markup(
+					vecty.Style("display", "contents"),
+				),
+				menuElement,
+			}, submenus...)...,
+		)
+	}
 	return menuElement
 }
 
@@ -118,6 +232,8 @@ func (c *M) Apply(h *vecty.HTML) {
 		c.MDC.Component = c.M
 		c.Open = open
 		c.QuickOpen = quickOpen
+	default:
+		c.reconcile()
 	}
 
 	vecty.Markup(
@@ -125,14 +241,19 @@ func (c *M) Apply(h *vecty.HTML) {
 		vecty.MarkupIf(c.Open,
 			vecty.Class("mdc-menu--open"),
 		),
-		vecty.Style("position", "absolute"),
+		vecty.MarkupIf(!c.fixed(),
+			vecty.Style("position", "absolute"),
+		),
+		vecty.MarkupIf(c.fixed(),
+			vecty.Style("position", "fixed"),
+		),
 		vecty.Attribute("tabindex", -1),
 		&vecty.EventListener{
-			Name:     "MDCMenu:selected",
+			Name:     mdcevent.MenuSelectedName,
 			Listener: c.onSelect,
 		},
 		&vecty.EventListener{
-			Name:     "MDCMenu:cancel",
+			Name:     mdcevent.MenuCancelName,
 			Listener: c.onCancel,
 		},
 	).Apply(h)
@@ -144,46 +265,176 @@ func (c *M) Apply(h *vecty.HTML) {
 	c.MDC.RootElement = h
 }
 
+// Mount implements the vecty.Mounter interface. It starts the MDC component,
+// applies the anchor options and listens for the submenu and context menu
+// events.
+func (c *M) Mount() {
+	c.MDC.Mount()
+	if !c.MDC.Started() {
+		return
+	}
+	c.patchAdapter()
+	c.patchDocumentClick()
+	c.reconcileAnchor()
+	c.listen()
+	c.reconcileContext()
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *M) Unmount() {
+	c.unlisten()
+	c.unlistenContext()
+	c.MDC.Unmount()
+}
+
+// reconcile pushes changes of the Go fields into the running MDC component,
+// which opens or closes the menu with its animation.
+func (c *M) reconcile() {
+	if c.M == nil || !c.MDC.Started() {
+		return
+	}
+	if c.M.QuickOpen != c.QuickOpen {
+		c.M.QuickOpen = c.QuickOpen
+	}
+	c.reconcileAnchor()
+	c.reconcileContext()
+	switch {
+	case c.M.Open == c.Open:
+	case c.Open && c.openFocusIndex() >= 0:
+		c.M.Component().Call("show", js.M{
+			"focusIndex": c.openFocusIndex(),
+		})
+	default:
+		c.M.Open = c.Open
+	}
+}
+
 func (c *M) onSelect(e *vecty.Event) {
-	if c.OnSelect != nil {
-		var item vecty.ComponentOrHTML
-		i := e.Get("detail").Get("index").Int()
-		i = i + c.dividerCountBefore(i)
-		switch t := c.List.(type) {
-		case *ul.L:
+	var item vecty.ComponentOrHTML
+	se := mdcevent.DecodeMenuSelected(e)
+	i := itemIndexOf(se.Item)
+	switch t := c.List.(type) {
+	case *ul.L:
+		if i >= 0 && i < len(t.Items) {
 			item = t.Items[i]
-		case vecty.List:
+		}
+	case vecty.List:
+		if i >= 0 && i < len(t) {
 			item = t[i]
 		}
-		c.OnSelect(i, item, e)
+	case *vecty.HTML:
+		// The items of a user supplied list are not marked.
+		c.selected([]int{se.Index}, nil, se)
+		return
+	}
+	if item == nil {
+		// The selected element is not an item of List, close the menu
+		// without reporting it.
+		c.selected(nil, nil, se)
+		return
+	}
+	c.selected([]int{i}, item, se)
+}
+
+// selected closes the menu and its submenus after item was selected at path
+// below c, and reports the selection to the root menu of the cascade. A nil
+// path is not reported.
+func (c *M) selected(path []int, item vecty.ComponentOrHTML,
+	se *mdcevent.MenuSelected) {
+	if c.parent != nil {
+		c.close()
+		if path != nil {
+			path = append([]int{c.parentIndex}, path...)
+		}
+		c.parent.selected(path, item, se)
+		return
 	}
+	c.closeSubmenus()
+	base.SyncState(c, c.Controlled,
+		func() { c.Open = false },
+		func() {
+			if c.OnSelect != nil && path != nil {
+				c.OnSelect(path, item, se)
+			}
+		},
+		c.restoreOpen,
+	)
 }
 
 func (c *M) onCancel(e *vecty.Event) {
-	if c.OnCancel != nil {
-		c.OnCancel(e)
+	if c.parent != nil {
+		c.close()
+		c.parent.focusItem(c.parentItem)
+		return
 	}
+	c.closeSubmenus()
+	// MDC sends the cancel event before it closes the menu.
+	base.SyncState(c, c.Controlled,
+		func() { c.Open = false },
+		func() {
+			if c.OnCancel != nil {
+				c.OnCancel(mdcevent.DecodeMenuCancel(e))
+			}
+		},
+		func() { js.Global.Call("setTimeout", c.restoreOpen, 0) },
+	)
 }
 
-// TODO: Figure out how to use or replicate MDC behavior
-// dividerCountBefore returns the number non-valid items (dividers) in the list
-// before itemIndex, non-inclusive.
-func (c *M) dividerCountBefore(itemIndex int) int {
-	count := 0
-	var items []vecty.ComponentOrHTML
-	switch t := c.List.(type) {
-	case *ul.L:
-		items = t.Items
-	case vecty.List:
-		items = t
+func (c *M) restoreOpen() {
+	if c.M != nil && c.M.Open != c.Open {
+		c.M.Open = c.Open
 	}
-	for i, item := range items {
-		if i > itemIndex {
-			break
-		}
-		if _, ok := item.(*base.StaticComponent); ok {
-			count++
+}
+
+// openFocusIndex returns the index among the MDC component's items of the item
+// to focus when the menu opens, or -1 to focus the menu itself.
+func (c *M) openFocusIndex() int {
+	switch {
+	case c.FocusItem:
+		return c.mdcIndex(c.FocusIndex)
+	case c.contextFocus:
+		return 0
+	}
+	return -1
+}
+
+// injectedRoot is the Root the menu gave to its list or one of its items, and
+// the Root the user gave it.
+type injectedRoot struct {
+	user   vecty.MarkupOrChild
+	markup *vecty.MarkupList
+}
+
+// itemIndexData marks the element of the item at index i in List, so that the
+// item can be found from the elements reported by MDC.
+func itemIndexData(i int) vecty.Applyer {
+	return vecty.Data("menuItemIndex", strconv.Itoa(i))
+}
+
+// itemIndexOf returns the index in List of the item rendered as node, or -1.
+func itemIndexOf(node *js.Object) int {
+	if node == nil || node == js.Undefined {
+		return -1
+	}
+	v := node.Get("dataset").Get("menuItemIndex")
+	if v == js.Undefined {
+		return -1
+	}
+	i, err := strconv.Atoi(v.String())
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
+// mdcIndex returns the index among the MDC component's items of the item at
+// index i in List, or -1.
+func (c *M) mdcIndex(i int) int {
+	items := c.M.Component().Get("items")
+	for j := 0; j < items.Length(); j++ {
+		if itemIndexOf(items.Index(j)) == i {
+			return j
 		}
 	}
-	return count
+	return -1
 }
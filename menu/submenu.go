@@ -0,0 +1,233 @@
+package menu
+
+import (
+	"agamigo.io/material/menu"
+	"agamigo.io/vecty-material/ul"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+)
+
+// renderSubmenus updates the submenus of the items of List that have Children
+// and returns them.
+func (c *M) renderSubmenus() []vecty.MarkupOrChild {
+	l, ok := c.List.(*ul.L)
+	if !ok {
+		return nil
+	}
+	var submenus []vecty.MarkupOrChild
+	current := make(map[*ul.Item]bool)
+	for i, li := range l.Items {
+		item, ok := li.(*ul.Item)
+		if !ok || len(item.Children) == 0 {
+			continue
+		}
+		if c.submenus == nil {
+			c.submenus = make(map[*ul.Item]*M)
+		}
+		sub := c.submenus[item]
+		if sub == nil {
+			sub = &M{
+				List:       &ul.L{},
+				Corner:     menu.TOP_END,
+				Fixed:      true,
+				parent:     c,
+				parentItem: item,
+			}
+			c.submenus[item] = sub
+		}
+		sub.parentIndex = i
+		sub.QuickOpen = c.QuickOpen
+		sub.Overlay = c.Overlay
+		sub.List.(*ul.L).Items = item.Children
+		sub.List.(*ul.L).Dense = l.Dense
+		current[item] = true
+		submenus = append(submenus, sub)
+	}
+	for item := range c.submenus {
+		if !current[item] {
+			delete(c.submenus, item)
+		}
+	}
+	return submenus
+}
+
+// patchDocumentClick makes the MDC menu treat clicks in its submenus as clicks
+// inside it. The submenus are rendered next to the menu, and MDC would cancel
+// the menu on a click that is not in its own element.
+func (c *M) patchDocumentClick() {
+	f := c.M.Component().Get("foundation_")
+	if f == js.Undefined {
+		return
+	}
+	handle := f.Get("handleDocumentClick_")
+	f.Set("handleDocumentClick_", func(e *js.Object) {
+		if c.contains(e.Get("target")) {
+			return
+		}
+		handle.Call("call", f, e)
+	})
+}
+
+// listen registers capture listeners on the MDC root element, which handle
+// the events that open and close submenus before the MDC component does.
+func (c *M) listen() {
+	root := c.M.Component().RootElement
+	c.listeners = []listener{
+		addListener(root, "click", true, c.captureClick),
+		addListener(root, "keydown", true, c.captureKeyDown),
+		addListener(root, "keyup", true, c.captureKeyUp),
+		addListener(root, "mouseover", true, c.captureMouseOver),
+	}
+}
+
+func (c *M) unlisten() {
+	for _, l := range c.listeners {
+		l.remove()
+	}
+	c.listeners = nil
+}
+
+func (c *M) captureClick(e *js.Object) {
+	item := c.submenuItemOf(e.Get("target"))
+	if item == nil {
+		return
+	}
+	// Keep MDC from selecting the item.
+	e.Call("stopPropagation")
+	c.openSubmenu(item, false)
+}
+
+func (c *M) captureKeyDown(e *js.Object) {
+	switch e.Get("key").String() {
+	case "ArrowRight", "Right", "Enter", " ", "Spacebar":
+		item := c.submenuItemOf(e.Get("target"))
+		if item == nil {
+			return
+		}
+		e.Call("stopPropagation")
+		e.Call("preventDefault")
+		c.openSubmenu(item, true)
+	case "ArrowLeft", "Left":
+		if c.parent == nil {
+			return
+		}
+		e.Call("stopPropagation")
+		e.Call("preventDefault")
+		c.close()
+		c.parent.focusItem(c.parentItem)
+	}
+}
+
+func (c *M) captureKeyUp(e *js.Object) {
+	switch e.Get("key").String() {
+	case "Enter", " ", "Spacebar":
+		// MDC selects items on keyup.
+		if c.submenuItemOf(e.Get("target")) != nil {
+			e.Call("stopPropagation")
+		}
+	}
+}
+
+func (c *M) captureMouseOver(e *js.Object) {
+	target := e.Get("target")
+	if item := c.submenuItemOf(target); item != nil {
+		c.openSubmenu(item, false)
+		return
+	}
+	l, ok := c.List.(*ul.L)
+	if !ok {
+		return
+	}
+	for _, li := range l.Items {
+		if item, ok := li.(*ul.Item); ok && contains(item, target) {
+			c.closeSubmenus()
+			return
+		}
+	}
+}
+
+// openSubmenu opens the submenu of item next to it and closes the others. If
+// focus is set, the first item of the submenu is focused.
+func (c *M) openSubmenu(item *ul.Item, focus bool) {
+	sub := c.submenus[item]
+	if sub == nil {
+		return
+	}
+	for other, s := range c.submenus {
+		if other != item {
+			s.close()
+		}
+	}
+	if sub.Open {
+		if focus {
+			sub.focusItem(sub.firstItem())
+		}
+		return
+	}
+	sub.AnchorRef = item.MDC.RootElement
+	sub.FocusItem = focus
+	sub.FocusIndex = 0
+	sub.Open = true
+	setExpanded(item, true)
+	vecty.Rerender(sub)
+}
+
+// close closes the submenu c and its own submenus.
+func (c *M) close() {
+	c.closeSubmenus()
+	if !c.Open {
+		return
+	}
+	c.Open = false
+	setExpanded(c.parentItem, false)
+	vecty.Rerender(c)
+}
+
+func (c *M) closeSubmenus() {
+	for _, sub := range c.submenus {
+		sub.close()
+	}
+}
+
+// submenuItemOf returns the item with a submenu containing node, or nil.
+func (c *M) submenuItemOf(node *js.Object) *ul.Item {
+	for item := range c.submenus {
+		if contains(item, node) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (c *M) firstItem() *ul.Item {
+	if l, ok := c.List.(*ul.L); ok {
+		for _, li := range l.Items {
+			if item, ok := li.(*ul.Item); ok && !item.Disabled {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+func (c *M) focusItem(item *ul.Item) {
+	if item != nil && item.MDC != nil && item.MDC.RootElement != nil {
+		item.MDC.RootElement.Node().Call("focus")
+	}
+}
+
+// contains reports whether node is the root element of item or inside it.
+func contains(item *ul.Item, node *js.Object) bool {
+	if item.MDC == nil || item.MDC.RootElement == nil {
+		return false
+	}
+	n := item.MDC.RootElement.Node()
+	return n == node || n.Call("contains", node).Bool()
+}
+
+func setExpanded(item *ul.Item, expanded bool) {
+	if item != nil && item.MDC != nil && item.MDC.RootElement != nil {
+		item.MDC.RootElement.Node().Call("setAttribute", "aria-expanded",
+			expanded)
+	}
+}
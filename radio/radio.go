@@ -4,6 +4,7 @@ import (
 	"agamigo.io/material/radio"
 	"agamigo.io/vecty-material/base"
 	"agamigo.io/vecty-material/base/applyer"
+	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
 	"github.com/gopherjs/vecty/event"
@@ -21,14 +22,22 @@ type R struct {
 	Checked  bool
 	Disabled bool
 	Value    string
+
+	// Controlled makes Checked the source of truth for the radio state. User
+	// interaction only calls OnChange, which should update the field and
+	// rerender. See base.SyncState.
+	Controlled bool
 }
 
+// groups holds the mounted radios by Name.
+var groups = make(map[string][]*R)
+
 // Render implements the vecty.Component interface.
 func (c *R) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("div", c.Root)
 	}
 
 	input, _ := c.NativeInput()
@@ -60,6 +69,8 @@ func (c *R) Apply(h *vecty.HTML) {
 			r.Disabled = c.Disabled
 			r.Value = c.Value
 		}
+	default:
+		c.reconcile()
 	}
 
 	vecty.Markup(
@@ -71,14 +82,81 @@ func (c *R) Apply(h *vecty.HTML) {
 	c.MDC.RootElement = h
 }
 
+// Mount implements the vecty.Mounter interface.
+func (c *R) Mount() {
+	c.MDC.Mount()
+	if c.Name != "" {
+		groups[c.Name] = append(groups[c.Name], c)
+	}
+}
+
+// Unmount implements the vecty.Unmounter interface.
+func (c *R) Unmount() {
+	for name, g := range groups {
+		for i, r := range g {
+			if r == c {
+				groups[name] = append(g[:i], g[i+1:]...)
+				break
+			}
+		}
+		if len(groups[name]) == 0 {
+			delete(groups, name)
+		}
+	}
+	c.MDC.Unmount()
+}
+
+// reconcile pushes changes of the Go fields into the running MDC component.
+func (c *R) reconcile() {
+	r, ok := c.MDC.Component.(*radio.R)
+	if !ok || !c.MDC.Started() {
+		return
+	}
+	if r.Checked != c.Checked {
+		r.Checked = c.Checked
+	}
+	if r.Disabled != c.Disabled {
+		r.Disabled = c.Disabled
+	}
+	if c.Value != "" && r.Value != c.Value {
+		r.Value = c.Value
+	}
+}
+
 func (c *R) onChange(e *vecty.Event) {
-	if r, ok := c.MDC.Component.(*radio.R); ok {
-		c.Checked = r.Checked
-		c.Disabled = r.Disabled
-		c.Value = r.Value
+	base.SyncState(c, c.Controlled,
+		func() {
+			c.Checked = e.Target.Get("checked").Bool()
+		},
+		func() {
+			if c.OnChange != nil {
+				c.OnChange(c, e)
+			}
+		},
+		func() {
+			e.Target.Set("checked", c.Checked)
+			c.restoreGroup(e.Target)
+		},
+	)
+}
+
+// restoreGroup restores the checked state of the controlled radios of the
+// group of c other than input. Checking a radio unchecks the others of its
+// group without a change event.
+func (c *R) restoreGroup(input *js.Object) {
+	if c.Name == "" {
+		return
 	}
-	if c.OnChange != nil {
-		c.OnChange(c, e)
+	for _, r := range groups[c.Name] {
+		if r == c || r.Name != c.Name || !r.Controlled ||
+			!r.MDC.Started() {
+			continue
+		}
+		n := r.MDC.RootElement.Node().Call("querySelector",
+			".mdc-radio__native-control")
+		if n != nil && n != input && n.Get("form") == input.Get("form") {
+			n.Set("checked", r.Checked)
+		}
 	}
 }
 
@@ -86,7 +164,10 @@ func (c *R) NativeInput() (element *vecty.HTML, id string) {
 	niMarkup := base.MarkupOnly(c.Input)
 	if c.Input != nil && niMarkup == nil {
 		// User supplied input element.
-		element = elem.Input(c.Input)
+		element, _ = base.UserRoot("input", c.Input).(*vecty.HTML)
+		if element == nil {
+			element = elem.Input(c.Input)
+		}
 		id = applyer.FindID(element)
 		return
 	}
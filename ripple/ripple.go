@@ -5,24 +5,102 @@ import (
 	"github.com/gopherjs/vecty"
 )
 
+// R is a vecty-material ripple. It is an Applyer, apply it to an element's
+// markup to give that element a ripple. The ripple is started when the
+// base.MDC the element belongs to mounts, and stopped when it unmounts.
+//
+// Interactive components (button.B, ul.Item) apply a ripple to their root
+// element unless their NoRipple field is set. Set their Ripple field to
+// configure it, or to call Activate, Deactivate and Layout.
 type R struct {
 	*ripple.R
 	Root      *vecty.HTML
 	Disabled  bool `js:"disabled"`
 	Unbounded bool `js:"unbounded"`
+
+	// Primary and Accent color the ripple with the theme's primary or accent
+	// color. Components whose styles already include a ripple keep their own
+	// color when neither is set.
+	Primary bool
+	Accent  bool
+
+	started bool
 }
 
 func (c *R) Apply(h *vecty.HTML) {
-	c.R = ripple.New()
+	if c.R == nil {
+		c.R = ripple.New()
+	}
 	c.Root = h
-	// start := func() error {
-	// 	return c.R.Start(c.Root.Node())
-	// }
-	// vecty.Property("vecty-material-ripple", js.InternalObject(start)).Apply(h)
-	// vecty.Property("vecty-material-ripple", &start).Apply(h)
-	vecty.Property("vecty-material-ripple", c).Apply(h)
+	if c.R.Unbounded != c.Unbounded {
+		c.R.Unbounded = c.Unbounded
+	}
+	if c.R.Disabled != c.Disabled {
+		c.R.Disabled = c.Disabled
+	}
+	vecty.Markup(
+		vecty.MarkupIf(c.Primary || c.Accent,
+			vecty.Class("mdc-ripple-surface"),
+		),
+		vecty.MarkupIf(c.Primary,
+			vecty.Class("mdc-ripple-surface--primary"),
+		),
+		vecty.MarkupIf(c.Accent,
+			vecty.Class("mdc-ripple-surface--accent"),
+		),
+		vecty.MarkupIf(c.Unbounded,
+			vecty.Data("mdcRippleIsUnbounded", "true"),
+		),
+		vecty.Property("vecty-material-ripple", c),
+	).Apply(h)
 }
 
+// Start starts the ripple on its Root element. It is a no-op if the ripple is
+// already started.
 func (c *R) Start() error {
-	return c.R.Start(c.Root.Node())
+	if c.started {
+		return nil
+	}
+	err := c.R.Start(c.Root.Node())
+	if err != nil {
+		return err
+	}
+	c.started = true
+	return nil
+}
+
+// Activate shows the ripple's activation, e.g. while a key is pressed. It is a
+// no-op if the ripple is not started.
+func (c *R) Activate() error {
+	if !c.started {
+		return nil
+	}
+	return c.R.Activate()
+}
+
+// Deactivate hides the ripple's activation. It is a no-op if the ripple is not
+// started.
+func (c *R) Deactivate() error {
+	if !c.started {
+		return nil
+	}
+	return c.R.Deactivate()
+}
+
+// Layout recomputes the ripple's dimensions after its element was resized. It
+// is a no-op if the ripple is not started.
+func (c *R) Layout() error {
+	if !c.started {
+		return nil
+	}
+	return c.R.Layout()
+}
+
+// Stop stops the ripple. It is a no-op if the ripple is not started.
+func (c *R) Stop() error {
+	if !c.started {
+		return nil
+	}
+	c.started = false
+	return c.R.Stop()
 }
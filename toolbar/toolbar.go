@@ -23,7 +23,7 @@ func (c *T) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Header(c.Root)
+		return base.UserRoot("header", c.Root)
 	}
 
 	// Built in root element.
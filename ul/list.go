@@ -2,6 +2,8 @@ package ul
 
 import (
 	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/icon"
+	"agamigo.io/vecty-material/ripple"
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/vecty"
 	"github.com/gopherjs/vecty/elem"
@@ -25,6 +27,38 @@ type L struct {
 	OnClick        func(thisL *L, thisI *Item, e *vecty.Event)
 	GroupSubheader string
 	twoLine        bool
+
+	// Selection enables keyboard navigation and the selection model of the
+	// list. The default, NoSelection, leaves keyboard input and selection to
+	// the user. See Selection.
+	Selection Selection
+
+	// OnSelectionChange is called with the indices in Items of the selected
+	// items when the user changes the selection.
+	OnSelectionChange func(thisL *L, selected []int)
+
+	// Reorderable lets the user move items by dragging their DragHandle, or
+	// with the keyboard: Space grabs the focused item, the arrow keys move it,
+	// Space or Enter drops it and Escape cancels. Space toggles the selection
+	// of lists whose items can be selected, Shift+Space grabs items there. It
+	// also enables keyboard navigation if Selection is NoSelection.
+	Reorderable bool
+
+	// OnReorder is called when the user moves the item at index from in Items
+	// to index to.
+	OnReorder func(from, to int)
+
+	// Controlled makes the Selected fields and the order of Items the source of
+	// truth for the selection and the order. User interaction only calls
+	// OnSelectionChange or OnReorder, which should update Items and rerender.
+	// See base.SyncState.
+	Controlled bool
+
+	focusIndex    int
+	focused       bool
+	typeahead     string
+	typeaheadTime float64
+	drag          *dragState
 }
 
 // Item is a vecty-material list-item component.
@@ -38,8 +72,53 @@ type Item struct {
 	Meta      vecty.ComponentOrHTML
 	Selected  bool
 	Activated bool
+	Disabled  bool
 	OnClick   func(i *Item, e *vecty.Event)
 	Href      string
+
+	// Ripple configures the ripple applied to the root element. A default
+	// ripple is created if it is nil, unless NoRipple is set.
+	Ripple   *ripple.R
+	NoRipple bool
+
+	// Children are the items nested under the item in a Tree. An item with
+	// Children or LoadChildren can be expanded to show them. In the List of a
+	// menu.M, Children are the items of the item's submenu.
+	Children []vecty.ComponentOrHTML
+
+	// Expanded shows the item's Children in a Tree.
+	Expanded bool
+
+	// LoadChildren is called by a Tree when the item is expanded while Children
+	// is nil, to load them lazily. It can set Children before it returns, or
+	// later followed by a vecty.Rerender of the Tree.
+	LoadChildren func(i *Item)
+
+	// SwipeLeft and SwipeRight are the leave-behind actions revealed when the
+	// item is swiped towards the left or the right. The item can only be
+	// swiped in the directions that have an action.
+	SwipeLeft  *SwipeAction
+	SwipeRight *SwipeAction
+
+	// OnSwipe is called when the item has been swiped away in direction d, and
+	// the action's undo window has passed. It usually removes the item.
+	OnSwipe func(i *Item, d SwipeDirection)
+
+	owner   itemOwner
+	index   int
+	parent  *Item
+	level   int
+	loading bool
+	groupID string
+	chevron *icon.I
+	swipe   swipeState
+}
+
+// itemOwner is implemented by the list components that render Items and
+// manage their keyboard focus and selection.
+type itemOwner interface {
+	// itemMarkup returns the markup the owner applies to item.
+	itemMarkup(item *Item) vecty.Applyer
 }
 
 // Group is a vecty-material list-group component.
@@ -59,23 +138,34 @@ func (c *L) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.UnorderedList(c.Root)
+		return base.UserRoot("ul", c.Root)
 	}
 
 	items := make([]vecty.MarkupOrChild, len(c.Items))
 	c.twoLine = false
+	if c.navigable() {
+		c.setupSelection()
+	}
 	for i, li := range c.Items {
+		row := li
 		switch t := li.(type) {
 		case *Item:
+			t.owner = c
+			t.index = i
 			if t.Secondary != nil {
 				c.twoLine = true
 			}
-			items[i] = t
+			if c.NonInteractive {
+				t.NoRipple = true
+			}
 		case *vecty.HTML:
-			items[i] = base.RenderStoredChild(t)
-		default:
-			items[i] = li
+			row = base.RenderStoredChild(t)
 		}
+		if c.Reorderable {
+			// Keyed rows let vecty move the items' elements with them.
+			row = vecty.List{row}.WithKey(rowKeyOf(li, i))
+		}
+		items[i] = row
 	}
 
 	root := elem.UnorderedList(items...)
@@ -102,6 +192,7 @@ func (c *L) Apply(h *vecty.HTML) {
 			vecty.Class("mdc-list--avatar-list")),
 		vecty.MarkupIf(c.NonInteractive,
 			vecty.Class("mdc-list--non-interactive")),
+		c.selectionMarkup(),
 	).Apply(h)
 	c.MDC.RootElement = h
 }
@@ -116,7 +207,7 @@ func (c *Item) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return vecty.Tag(tag, c.Root)
+		return base.UserRoot(tag, c.Root)
 	}
 
 	graphic := setupGraphicOrMeta(c.Graphic)
@@ -135,6 +226,11 @@ func (c *Item) Render() vecty.ComponentOrHTML {
 		}
 	}
 
+	var chevron vecty.ComponentOrHTML
+	if _, ok := c.owner.(*Tree); ok && c.expandable() {
+		chevron = c.renderChevron(meta == nil)
+	}
+
 	var text vecty.ComponentOrHTML
 	switch {
 	case c.Secondary != nil:
@@ -154,8 +250,10 @@ func (c *Item) Render() vecty.ComponentOrHTML {
 			vecty.MarkupIf(rootMarkup != nil, *rootMarkup),
 		),
 		graphic,
-		base.RenderStoredChild(text),
+		base.RenderStoredChildren(text),
 		meta,
+		chevron,
+		c.renderLeaveBehind(),
 	)
 }
 
@@ -171,11 +269,22 @@ func (c *Item) Apply(h *vecty.HTML) {
 			vecty.Class("mdc-list-item--selected")),
 		vecty.MarkupIf(c.Activated,
 			vecty.Class("mdc-list-item--activated")),
+		vecty.MarkupIf(c.Disabled,
+			vecty.Class("mdc-list-item--disabled"),
+			vecty.Attribute("aria-disabled", "true")),
+		c.ownerMarkup(),
+		c.swipeMarkup(),
 		vecty.MarkupIf(c.OnClick != nil,
 			event.Click(c.wrapOnClick()),
 		),
 		vecty.MarkupIf(c.Href != "", prop.Href(c.Href)),
 	).Apply(h)
+	if !c.NoRipple {
+		if c.Ripple == nil {
+			c.Ripple = &ripple.R{}
+		}
+		c.Ripple.Apply(h)
+	}
 	c.MDC.RootElement = h
 }
 
@@ -184,7 +293,7 @@ func (c *Group) Render() vecty.ComponentOrHTML {
 	rootMarkup := base.MarkupOnly(c.Root)
 	if c.Root != nil && rootMarkup == nil {
 		// User supplied root element.
-		return elem.Div(c.Root)
+		return base.UserRoot("div", c.Root)
 	}
 
 	return elem.Div(
@@ -0,0 +1,241 @@
+package ul
+
+import (
+	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/icon"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/event"
+)
+
+// Box shadows drawing the line where a moved item will drop.
+const (
+	dropAbove = "inset 0 2px 0 0 var(--mdc-theme-primary, #3f51b5)"
+	dropBelow = "inset 0 -2px 0 0 var(--mdc-theme-primary, #3f51b5)"
+)
+
+// dragState is the state of an item being moved in a Reorderable list.
+type dragState struct {
+	from, to  int
+	pointer   bool
+	pointerID int
+}
+
+// listRowKey identifies a row of a Reorderable list that cannot be its own
+// key.
+type listRowKey struct {
+	index int
+}
+
+// DragHandle returns a drag handle icon to use as the Graphic or Meta of an
+// item in a Reorderable list. Items can only be dragged by their handle.
+func DragHandle() vecty.ComponentOrHTML {
+	return &icon.I{
+		Name: "drag_handle",
+		Root: vecty.Markup(
+			vecty.Data("listDragHandle", "true"),
+			vecty.Style("cursor", "grab"),
+			vecty.Style("touch-action", "none"),
+		),
+	}
+}
+
+func (c *L) reorderMarkup() vecty.Applyer {
+	if !c.Reorderable {
+		return nil
+	}
+	return vecty.Markup(
+		event.PointerDown(c.onPointerDown),
+		event.PointerMove(c.onPointerMove),
+		event.PointerUp(c.onPointerUp),
+		event.PointerCancel(c.onPointerCancel),
+	)
+}
+
+// reorderItemMarkup marks the item being moved, and draws the drop indicator
+// on the side of the item it will take the place of.
+func (c *L) reorderItemMarkup(item *Item) vecty.Applyer {
+	d := c.drag
+	if d == nil {
+		return nil
+	}
+	return vecty.Markup(
+		vecty.MarkupIf(item.index == d.from,
+			vecty.Attribute("aria-grabbed", "true"),
+			vecty.Style("opacity", "0.6")),
+		vecty.MarkupIf(item.index == d.to && d.to < d.from,
+			vecty.Style("box-shadow", dropAbove)),
+		vecty.MarkupIf(item.index == d.to && d.to > d.from,
+			vecty.Style("box-shadow", dropBelow)),
+	)
+}
+
+// selectable reports whether the items of c can be selected with Space.
+func (c *L) selectable() bool {
+	return c.Selection != NoSelection && c.Selection != NavigateOnly
+}
+
+// reorderKey handles the keyboard reordering keys pressed on the item at index
+// i, and reports whether the key was handled.
+func (c *L) reorderKey(e *vecty.Event, i int) bool {
+	key := e.Get("key").String()
+	d := c.drag
+	switch {
+	case d == nil:
+		if key != " " && key != "Spacebar" || !c.focusable(i) {
+			return false
+		}
+		if c.selectable() && !e.Get("shiftKey").Bool() {
+			// Space selects the item.
+			return false
+		}
+		c.drag = &dragState{from: i, to: i}
+		vecty.Rerender(c)
+		return true
+	case d.pointer:
+		if key == "Escape" || key == "Esc" {
+			c.drag = nil
+			vecty.Rerender(c)
+			return true
+		}
+		return false
+	}
+
+	switch key {
+	case "ArrowDown", "Down":
+		if d.to < len(c.Items)-1 {
+			d.to++
+		}
+	case "ArrowUp", "Up":
+		if d.to > 0 {
+			d.to--
+		}
+	case "Home":
+		d.to = 0
+	case "End":
+		d.to = len(c.Items) - 1
+	case " ", "Spacebar", "Enter":
+		c.drag = nil
+		c.reorder(d.from, d.to)
+		return true
+	case "Escape", "Esc":
+		c.drag = nil
+	case "Tab":
+		c.drag = nil
+		vecty.Rerender(c)
+		return false
+	}
+	vecty.Rerender(c)
+	return true
+}
+
+func (c *L) onPointerDown(e *vecty.Event) {
+	if c.drag != nil || e.Get("button").Int() != 0 {
+		return
+	}
+	handle := e.Target.Call("closest", "[data-list-drag-handle]")
+	if handle == nil {
+		return
+	}
+	i := c.itemIndexOf(handle)
+	if !c.focusable(i) {
+		return
+	}
+	c.drag = &dragState{
+		from:      i,
+		to:        i,
+		pointer:   true,
+		pointerID: e.Get("pointerId").Int(),
+	}
+	c.focusIndex = i
+	c.focused = true
+	capturePointer(c.MDC.RootElement.Node(), c.drag.pointerID)
+	e.Call("preventDefault")
+	vecty.Rerender(c)
+}
+
+func (c *L) onPointerMove(e *vecty.Event) {
+	d := c.drag
+	if d == nil || !d.pointer || e.Get("pointerId").Int() != d.pointerID {
+		return
+	}
+	y := e.Get("clientY").Float()
+	children := c.MDC.RootElement.Node().Get("children")
+	if children.Length() != len(c.Items) {
+		return
+	}
+	// The moved item lands after every other item whose middle is above y.
+	to := 0
+	for i := range c.Items {
+		r := children.Index(i).Call("getBoundingClientRect")
+		if i != d.from && r.Get("top").Float()+r.Get("height").Float()/2 < y {
+			to++
+		}
+	}
+	if to != d.to {
+		d.to = to
+		vecty.Rerender(c)
+	}
+}
+
+func (c *L) onPointerUp(e *vecty.Event) {
+	d := c.drag
+	if d == nil || !d.pointer || e.Get("pointerId").Int() != d.pointerID {
+		return
+	}
+	c.drag = nil
+	c.reorder(d.from, d.to)
+}
+
+func (c *L) onPointerCancel(e *vecty.Event) {
+	if c.drag == nil || !c.drag.pointer {
+		return
+	}
+	c.drag = nil
+	vecty.Rerender(c)
+}
+
+// reorder moves the item at index from in Items to index to, then reports it
+// with OnReorder.
+func (c *L) reorder(from, to int) {
+	if from == to {
+		vecty.Rerender(c)
+		return
+	}
+	base.SyncState(c, c.Controlled,
+		func() { moveItem(c.Items, from, to) },
+		func() {
+			if c.OnReorder != nil {
+				c.OnReorder(from, to)
+			}
+		},
+		func() { vecty.Rerender(c) },
+	)
+	c.focusIndex = to
+	c.focused = true
+	// Moving the item's element can blur it, focus it again once the list is
+	// rendered.
+	js.Global.Call("requestAnimationFrame", func() {
+		c.focusItem(c.focusIndex)
+	})
+}
+
+// moveItem moves items[from] to index to, shifting the items in between.
+func moveItem(items []vecty.ComponentOrHTML, from, to int) {
+	item := items[from]
+	if from < to {
+		copy(items[from:to], items[from+1:to+1])
+	} else {
+		copy(items[to+1:from+1], items[to:from])
+	}
+	items[to] = item
+}
+
+// rowKeyOf returns the key of the row rendering li, the i-th entry of Items.
+func rowKeyOf(li vecty.ComponentOrHTML, i int) interface{} {
+	switch li.(type) {
+	case *Item, *base.StaticComponent, *vecty.HTML:
+		return li
+	}
+	return listRowKey{index: i}
+}
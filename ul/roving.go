@@ -0,0 +1,34 @@
+package ul
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// nextFocusable returns the index of the first of n rows after from in
+// direction dir (1 or -1) for which focusable returns true, or -1 if there is
+// none. L, VirtualL and Tree move their roving tabindex with it.
+func nextFocusable(n, from, dir int, focusable func(i int) bool) int {
+	for i := from + dir; i >= 0 && i < n; i += dir {
+		if focusable(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// contains reports whether node is the element of the rendered item c, or is
+// inside it.
+func (c *Item) contains(node *js.Object) bool {
+	if c.MDC == nil || c.MDC.RootElement == nil {
+		return false
+	}
+	n := c.MDC.RootElement.Node()
+	return n == node || n.Call("contains", node).Bool()
+}
+
+// focusNode focuses the element of item, if it is rendered.
+func focusNode(item *Item) {
+	if item != nil && item.MDC != nil && item.MDC.RootElement != nil {
+		item.MDC.RootElement.Node().Call("focus")
+	}
+}
@@ -0,0 +1,328 @@
+package ul
+
+import (
+	"strings"
+
+	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/checkbox"
+	"agamigo.io/vecty-material/radio"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/event"
+)
+
+// Selection is the keyboard and selection behavior of a list.
+type Selection int
+
+const (
+	// NoSelection lists do not handle keyboard input or selection.
+	NoSelection Selection = iota
+
+	// NavigateOnly lists have a roving tabindex, arrow/Home/End navigation
+	// and typeahead by primary text, but no selection.
+	NavigateOnly
+
+	// SingleSelection lists are listboxes with one selected item. Clicking an
+	// item, or pressing Enter or Space on it, selects it.
+	SingleSelection
+
+	// RadioSelection lists are radio groups with one checked item. A radio.R in
+	// an item's Graphic or Meta is kept in sync with the item.
+	RadioSelection
+
+	// MultiSelection lists are groups of checkbox items that are toggled
+	// individually. A checkbox.CB in an item's Graphic or Meta is kept in sync
+	// with the item.
+	MultiSelection
+)
+
+// typeaheadTimeout is the time in milliseconds after which typed characters
+// start a new typeahead search.
+const typeaheadTimeout = 500
+
+// Selected returns the indices in Items of the selected items.
+func (c *L) Selected() []int {
+	var selected []int
+	for i, li := range c.Items {
+		if item, ok := li.(*Item); ok && item.Selected {
+			selected = append(selected, i)
+		}
+	}
+	return selected
+}
+
+// setupSelection makes sure the item holding the roving tabindex can be
+// focused, and syncs the checkboxes/radios embedded in items with the
+// selection.
+func (c *L) setupSelection() {
+	if !c.focused || !c.focusable(c.focusIndex) {
+		c.focusIndex = -1
+		for _, i := range c.Selected() {
+			if c.focusable(i) {
+				c.focusIndex = i
+				break
+			}
+		}
+		if c.focusIndex == -1 {
+			c.focusIndex = c.nextFocusable(-1, 1)
+		}
+	}
+	if c.Selection != RadioSelection && c.Selection != MultiSelection {
+		return
+	}
+	for _, li := range c.Items {
+		item, ok := li.(*Item)
+		if !ok {
+			continue
+		}
+		for _, ctrl := range []vecty.ComponentOrHTML{item.Graphic, item.Meta} {
+			switch t := ctrl.(type) {
+			case *checkbox.CB:
+				t.Checked = item.Selected
+			case *radio.R:
+				t.Checked = item.Selected
+			}
+		}
+	}
+}
+
+func (c *L) selectionMarkup() vecty.Applyer {
+	if !c.navigable() {
+		return nil
+	}
+	var role string
+	switch c.Selection {
+	case SingleSelection:
+		role = "listbox"
+	case RadioSelection:
+		role = "radiogroup"
+	case MultiSelection:
+		role = "group"
+	}
+	return vecty.Markup(
+		vecty.MarkupIf(role != "", vecty.Attribute("role", role)),
+		event.KeyDown(c.onKeyDown),
+		vecty.MarkupIf(c.Selection != NoSelection,
+			event.Click(c.onItemClick)),
+		c.reorderMarkup(),
+	)
+}
+
+// navigable reports whether the list has a roving tabindex and keyboard
+// navigation.
+func (c *L) navigable() bool {
+	return c.Selection != NoSelection || c.Reorderable
+}
+
+func (c *Item) ownerMarkup() vecty.Applyer {
+	if c.owner == nil {
+		return nil
+	}
+	return c.owner.itemMarkup(c)
+}
+
+// itemMarkup implements the itemOwner interface.
+func (c *L) itemMarkup(item *Item) vecty.Applyer {
+	if !c.navigable() {
+		return nil
+	}
+	tabindex := -1
+	if item.index == c.focusIndex {
+		tabindex = 0
+	}
+	markup := []vecty.Applyer{
+		vecty.Attribute("tabindex", tabindex),
+		c.reorderItemMarkup(item),
+	}
+	switch c.Selection {
+	case SingleSelection:
+		markup = append(markup,
+			vecty.Attribute("role", "option"),
+			vecty.Attribute("aria-selected", item.Selected),
+		)
+	case RadioSelection:
+		markup = append(markup,
+			vecty.Attribute("role", "radio"),
+			vecty.Attribute("aria-checked", item.Selected),
+		)
+	case MultiSelection:
+		markup = append(markup,
+			vecty.Attribute("role", "checkbox"),
+			vecty.Attribute("aria-checked", item.Selected),
+		)
+	}
+	return vecty.Markup(markup...)
+}
+
+func (c *L) onKeyDown(e *vecty.Event) {
+	i := c.itemIndexOf(e.Target)
+	if i < 0 {
+		return
+	}
+	c.focusIndex = i
+	c.focused = true
+	if c.Reorderable && c.reorderKey(e, i) {
+		e.Call("preventDefault")
+		return
+	}
+	key := e.Get("key").String()
+	switch key {
+	case "ArrowDown", "Down":
+		c.focusItem(c.nextFocusable(i, 1))
+	case "ArrowUp", "Up":
+		c.focusItem(c.nextFocusable(i, -1))
+	case "Home":
+		c.focusItem(c.nextFocusable(-1, 1))
+	case "End":
+		c.focusItem(c.nextFocusable(len(c.Items), -1))
+	case "Enter", " ", "Spacebar":
+		if !c.selectable() {
+			return
+		}
+		c.toggle(i)
+	default:
+		if len([]rune(key)) != 1 || e.Get("ctrlKey").Bool() ||
+			e.Get("metaKey").Bool() || e.Get("altKey").Bool() {
+			return
+		}
+		c.typeaheadKey(key)
+	}
+	e.Call("preventDefault")
+}
+
+func (c *L) onItemClick(e *vecty.Event) {
+	i := c.itemIndexOf(e.Target)
+	if i < 0 || !c.focusable(i) {
+		return
+	}
+	c.focusIndex = i
+	c.focused = true
+	if c.Selection == NavigateOnly {
+		vecty.Rerender(c)
+		return
+	}
+	c.toggle(i)
+}
+
+// toggle changes the selection state of the item at index i according to the
+// list's Selection, then reports the new selection with OnSelectionChange.
+func (c *L) toggle(i int) {
+	var selected []int
+	switch c.Selection {
+	case MultiSelection:
+		for _, j := range c.Selected() {
+			if j != i {
+				selected = append(selected, j)
+			}
+		}
+		if !c.Items[i].(*Item).Selected {
+			selected = append(selected, i)
+		}
+	default:
+		selected = []int{i}
+	}
+	base.SyncState(c, c.Controlled,
+		func() {
+			for j, li := range c.Items {
+				if item, ok := li.(*Item); ok {
+					item.Selected = containsIndex(selected, j)
+				}
+			}
+		},
+		func() {
+			if c.OnSelectionChange != nil {
+				c.OnSelectionChange(c, selected)
+			}
+		},
+		func() {
+			// Undo changes made by embedded checkboxes/radios.
+			vecty.Rerender(c)
+		},
+	)
+}
+
+func (c *L) typeaheadKey(key string) {
+	now := js.Global.Get("Date").Call("now").Float()
+	if now-c.typeaheadTime > typeaheadTimeout {
+		c.typeahead = ""
+	}
+	c.typeaheadTime = now
+	c.typeahead += strings.ToLower(key)
+
+	start := c.focusIndex
+	if len([]rune(c.typeahead)) == 1 {
+		// A new search starts after the focused item, so that typing the same
+		// character repeatedly cycles through matching items.
+		start++
+	}
+	for n := 0; n < len(c.Items); n++ {
+		i := (start + n) % len(c.Items)
+		if i < 0 || !c.focusable(i) {
+			continue
+		}
+		text := strings.TrimSpace(c.Items[i].(*Item).primaryText())
+		if strings.HasPrefix(strings.ToLower(text), c.typeahead) {
+			c.focusItem(i)
+			return
+		}
+	}
+}
+
+// focusItem moves the roving tabindex and the focus to the item at index i.
+func (c *L) focusItem(i int) {
+	if !c.focusable(i) {
+		return
+	}
+	c.focusIndex = i
+	c.focused = true
+	focusNode(c.Items[i].(*Item))
+	vecty.Rerender(c)
+}
+
+func (c *L) nextFocusable(from, dir int) int {
+	return nextFocusable(len(c.Items), from, dir, c.focusable)
+}
+
+// focusable reports whether Items[i] is an enabled *Item.
+func (c *L) focusable(i int) bool {
+	if i < 0 || i >= len(c.Items) {
+		return false
+	}
+	item, ok := c.Items[i].(*Item)
+	return ok && !item.Disabled
+}
+
+// itemIndexOf returns the index in Items of the item containing node, or -1.
+func (c *L) itemIndexOf(node *js.Object) int {
+	for i, li := range c.Items {
+		if item, ok := li.(*Item); ok && item.contains(node) {
+			return i
+		}
+	}
+	return -1
+}
+
+// primaryText returns the rendered text of the item's Primary element, used
+// for typeahead.
+func (c *Item) primaryText() (text string) {
+	h, ok := c.Primary.(*vecty.HTML)
+	if !ok || h == nil {
+		return ""
+	}
+	defer func() {
+		// Primary has not been rendered yet.
+		if recover() != nil {
+			text = ""
+		}
+	}()
+	return h.Node().Get("textContent").String()
+}
+
+func containsIndex(indices []int, i int) bool {
+	for _, v := range indices {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,334 @@
+package ul
+
+import (
+	"math"
+	"strconv"
+
+	"agamigo.io/vecty-material/icon"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+)
+
+// SwipeDirection is the direction an item is swiped towards.
+type SwipeDirection int
+
+const (
+	// SwipedLeft items are swiped towards the left, revealing their
+	// SwipeLeft action on the right.
+	SwipedLeft SwipeDirection = iota + 1
+
+	// SwipedRight items are swiped towards the right, revealing their
+	// SwipeRight action on the left.
+	SwipedRight
+)
+
+const (
+	// swipeSlop is the distance in pixels a pointer moves before it is known
+	// whether it swipes the item or scrolls the page.
+	swipeSlop = 8
+
+	// swipeDuration is the duration in milliseconds of the swipe animations.
+	swipeDuration = 200
+)
+
+// SwipeAction is a leave-behind action of an item, revealed when the item is
+// swiped. See Item.SwipeLeft and Item.SwipeRight.
+type SwipeAction struct {
+	// Icon is the name of the material icon shown on the leave-behind.
+	Icon string
+
+	// Color is the CSS background color of the leave-behind. The default is
+	// the theme's secondary color.
+	Color string
+
+	// Threshold is the fraction of the item's width it has to be swiped for
+	// the action to happen when it is released. The default is 0.4.
+	Threshold float64
+
+	// UndoTimeout is the time in milliseconds during which a swiped item shows
+	// an undo button before OnSwipe is called. If it is 0, OnSwipe is called
+	// once the item has left.
+	UndoTimeout int
+
+	// UndoLabel is the label of the undo button. The default is "Undo".
+	UndoLabel string
+
+	icon *icon.I
+}
+
+// swipeState is the state of an item being swiped.
+type swipeState struct {
+	active         bool
+	tracking       bool
+	pointerID      int
+	startX, startY float64
+	dx, width      float64
+	dismissed      SwipeDirection
+	timer          int
+}
+
+// Swipe swipes the item away in direction d as if the user did, for example
+// from a keyboard shortcut. It is a no-op if the item has no action for d.
+func (c *Item) Swipe(d SwipeDirection) {
+	if !c.swipeable() || c.swipeAction(d) == nil || c.swipe.dismissed != 0 {
+		return
+	}
+	if c.MDC != nil && c.MDC.RootElement != nil {
+		c.swipe.width = c.MDC.RootElement.Node().Get("offsetWidth").Float()
+	}
+	c.dismiss(d)
+}
+
+func (c *Item) swipeable() bool {
+	return (c.SwipeLeft != nil || c.SwipeRight != nil) && !c.Disabled
+}
+
+func (c *Item) swipeAction(d SwipeDirection) *SwipeAction {
+	switch d {
+	case SwipedLeft:
+		return c.SwipeLeft
+	case SwipedRight:
+		return c.SwipeRight
+	}
+	return nil
+}
+
+func (c *Item) swipeMarkup() vecty.Applyer {
+	if !c.swipeable() {
+		return nil
+	}
+	s := &c.swipe
+	return vecty.Markup(
+		vecty.Style("position", "relative"),
+		vecty.Style("touch-action", "pan-y"),
+		vecty.MarkupIf(s.dx != 0,
+			vecty.Style("transform", "translateX("+px(s.dx)+")")),
+		vecty.MarkupIf(!s.tracking,
+			vecty.Style("transition",
+				"transform "+strconv.Itoa(swipeDuration)+"ms")),
+		event.PointerDown(c.onSwipeStart),
+		event.PointerMove(c.onSwipeMove),
+		event.PointerUp(c.onSwipeEnd),
+		event.PointerCancel(c.onSwipeCancel),
+	)
+}
+
+// renderLeaveBehind returns the leave-behind of the action being revealed. It
+// fills the space the item has been swiped away from.
+func (c *Item) renderLeaveBehind() vecty.ComponentOrHTML {
+	s := &c.swipe
+	d := s.dismissed
+	if d == 0 {
+		d = directionOf(s.dx)
+	}
+	a := c.swipeAction(d)
+	if a == nil || s.dx == 0 {
+		return nil
+	}
+
+	side, justify := "left", "flex-start"
+	if d == SwipedLeft {
+		side, justify = "right", "flex-end"
+	}
+	color := a.Color
+	if color == "" {
+		color = "var(--mdc-theme-secondary, #ff4081)"
+	}
+	label := a.UndoLabel
+	if label == "" {
+		label = "Undo"
+	}
+	if a.icon == nil {
+		a.icon = &icon.I{}
+	}
+	a.icon.Name = a.Icon
+	undo := s.dismissed != 0 && a.UndoTimeout > 0
+	width := math.Abs(s.dx)
+
+	return elem.Span(
+		vecty.Markup(
+			vecty.Style("position", "absolute"),
+			vecty.Style("top", "0"),
+			vecty.Style("bottom", "0"),
+			vecty.Style(side, px(-width)),
+			vecty.Style("width", px(width)),
+			vecty.Style("display", "flex"),
+			vecty.Style("align-items", "center"),
+			vecty.Style("justify-content", justify),
+			vecty.Style("padding", "0 16px"),
+			vecty.Style("box-sizing", "border-box"),
+			vecty.Style("overflow", "hidden"),
+			vecty.Style("background-color", color),
+			vecty.Style("color", "#fff"),
+			vecty.MarkupIf(!undo, vecty.Attribute("aria-hidden", "true")),
+		),
+		vecty.If(a.Icon != "", a.icon),
+		vecty.If(undo, elem.Button(
+			vecty.Markup(
+				vecty.Class("mdc-button"),
+				vecty.Style("color", "inherit"),
+				vecty.Style("margin", "0 8px"),
+				event.Click(c.onUndo).StopPropagation(),
+			),
+			vecty.Text(label),
+		)),
+	)
+}
+
+func (c *Item) onSwipeStart(e *vecty.Event) {
+	s := &c.swipe
+	if s.active || s.dismissed != 0 || e.Get("button").Int() != 0 {
+		return
+	}
+	if e.Target.Call("closest", "[data-list-drag-handle]") != nil {
+		return
+	}
+	*s = swipeState{
+		active:    true,
+		pointerID: e.Get("pointerId").Int(),
+		startX:    e.Get("clientX").Float(),
+		startY:    e.Get("clientY").Float(),
+		width:     c.MDC.RootElement.Node().Get("offsetWidth").Float(),
+	}
+}
+
+func (c *Item) onSwipeMove(e *vecty.Event) {
+	s := &c.swipe
+	if !s.active || e.Get("pointerId").Int() != s.pointerID {
+		return
+	}
+	dx := e.Get("clientX").Float() - s.startX
+	dy := e.Get("clientY").Float() - s.startY
+	if !s.tracking {
+		switch {
+		case math.Abs(dy) > swipeSlop && math.Abs(dy) > math.Abs(dx):
+			// The pointer scrolls the page.
+			s.active = false
+			return
+		case math.Abs(dx) > swipeSlop && c.swipeAction(directionOf(dx)) != nil:
+			s.tracking = true
+			capturePointer(c.MDC.RootElement.Node(), s.pointerID)
+		default:
+			return
+		}
+	}
+	if c.swipeAction(directionOf(dx)) == nil {
+		dx = 0
+	}
+	s.dx = dx
+	e.Call("preventDefault")
+	vecty.Rerender(c)
+}
+
+func (c *Item) onSwipeEnd(e *vecty.Event) {
+	s := &c.swipe
+	if !s.active || e.Get("pointerId").Int() != s.pointerID {
+		return
+	}
+	s.active = false
+	if !s.tracking {
+		return
+	}
+	s.tracking = false
+	suppressClick(c.MDC.RootElement.Node())
+
+	d := directionOf(s.dx)
+	a := c.swipeAction(d)
+	if a == nil {
+		s.dx = 0
+		vecty.Rerender(c)
+		return
+	}
+	threshold := a.Threshold
+	if threshold == 0 {
+		threshold = 0.4
+	}
+	if math.Abs(s.dx) < threshold*s.width {
+		s.dx = 0
+		vecty.Rerender(c)
+		return
+	}
+	c.dismiss(d)
+}
+
+func (c *Item) onSwipeCancel(e *vecty.Event) {
+	s := &c.swipe
+	if !s.active || s.dismissed != 0 {
+		return
+	}
+	s.active = false
+	s.tracking = false
+	s.dx = 0
+	vecty.Rerender(c)
+}
+
+// dismiss moves the item out in direction d, then calls OnSwipe after the undo
+// window of the action.
+func (c *Item) dismiss(d SwipeDirection) {
+	s := &c.swipe
+	s.dismissed = d
+	s.dx = s.width
+	if d == SwipedLeft {
+		s.dx = -s.width
+	}
+	timeout := c.swipeAction(d).UndoTimeout
+	if timeout == 0 {
+		timeout = swipeDuration
+	}
+	s.timer = js.Global.Call("setTimeout", c.commitSwipe, timeout).Int()
+	vecty.Rerender(c)
+}
+
+func (c *Item) commitSwipe() {
+	d := c.swipe.dismissed
+	c.swipe = swipeState{}
+	if c.OnSwipe != nil {
+		c.OnSwipe(c, d)
+	}
+	vecty.Rerender(c)
+}
+
+func (c *Item) onUndo(e *vecty.Event) {
+	js.Global.Call("clearTimeout", c.swipe.timer)
+	c.swipe = swipeState{}
+	vecty.Rerender(c)
+}
+
+func directionOf(dx float64) SwipeDirection {
+	switch {
+	case dx < 0:
+		return SwipedLeft
+	case dx > 0:
+		return SwipedRight
+	}
+	return 0
+}
+
+// capturePointer sends the further events of pointer id to node. Synthetic
+// pointer events have no active pointer to capture, which is ignored.
+func capturePointer(node *js.Object, id int) {
+	defer func() { recover() }()
+	node.Call("setPointerCapture", id)
+}
+
+// suppressClick stops the click that ends a gesture on node from reaching the
+// click handlers of node and its ancestors.
+func suppressClick(node *js.Object) {
+	opts := js.M{"capture": true, "once": true}
+	l := js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		args[0].Call("stopImmediatePropagation")
+		args[0].Call("preventDefault")
+		return nil
+	})
+	node.Call("addEventListener", "click", l, opts)
+	// The click, if any, is dispatched with the pointerup event.
+	js.Global.Call("setTimeout", func() {
+		node.Call("removeEventListener", "click", l, opts)
+	}, 0)
+}
+
+func px(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64) + "px"
+}
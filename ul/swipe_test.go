@@ -0,0 +1,130 @@
+//go:build js
+// +build js
+
+package ul
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+)
+
+// The tests need a document, see testdata/dom.js.
+
+type swipeBody struct {
+	vecty.Core
+	item *Item
+}
+
+func (c *swipeBody) Render() vecty.ComponentOrHTML {
+	return elem.Body(&L{Items: []vecty.ComponentOrHTML{c.item}})
+}
+
+// renderSwipeItem renders item as the only item of a list, and returns its
+// element, which is given a width since jsdom does no layout.
+func renderSwipeItem(item *Item) *js.Object {
+	item.NoRipple = true
+	vecty.RenderBody(&swipeBody{item: item})
+	node := item.MDC.RootElement.Node()
+	js.Global.Get("Object").Call("defineProperty", node, "offsetWidth",
+		js.M{"value": 300})
+	return node
+}
+
+// pointer dispatches a pointer event of type typ at clientX x on node.
+func pointer(node *js.Object, typ string, x float64) {
+	e := js.Global.Get("window").Get("Event").New(typ,
+		js.M{"bubbles": true, "cancelable": true})
+	e.Set("pointerId", 1)
+	e.Set("button", 0)
+	e.Set("clientX", x)
+	e.Set("clientY", 10)
+	node.Call("dispatchEvent", e)
+}
+
+func swipe(node *js.Object, from, to float64) {
+	pointer(node, "pointerdown", from)
+	pointer(node, "pointermove", (from+to)/2)
+	pointer(node, "pointermove", to)
+	pointer(node, "pointerup", to)
+}
+
+func TestSwipeOnSwipe(t *testing.T) {
+	var swiped []SwipeDirection
+	item := &Item{
+		Primary:   vecty.Text("Item"),
+		SwipeLeft: &SwipeAction{Icon: "delete"},
+		OnSwipe: func(i *Item, d SwipeDirection) {
+			swiped = append(swiped, d)
+		},
+	}
+	node := renderSwipeItem(item)
+
+	// A swipe below the threshold moves the item back.
+	swipe(node, 200, 150)
+	time.Sleep(2 * swipeDuration * time.Millisecond)
+	if len(swiped) != 0 {
+		t.Fatalf("OnSwipe called after a short swipe: %v", swiped)
+	}
+	if item.swipe.dx != 0 || item.swipe.dismissed != 0 {
+		t.Fatalf("item not reset after a short swipe: %+v", item.swipe)
+	}
+
+	// There is no action to swipe the item right.
+	swipe(node, 50, 250)
+	time.Sleep(2 * swipeDuration * time.Millisecond)
+	if len(swiped) != 0 {
+		t.Fatalf("OnSwipe called without a SwipeRight: %v", swiped)
+	}
+
+	swipe(node, 250, 50)
+	if item.swipe.dismissed != SwipedLeft {
+		t.Fatalf("dismissed = %v, want %v", item.swipe.dismissed, SwipedLeft)
+	}
+	time.Sleep(2 * swipeDuration * time.Millisecond)
+	if len(swiped) != 1 || swiped[0] != SwipedLeft {
+		t.Fatalf("OnSwipe calls = %v, want [%v]", swiped, SwipedLeft)
+	}
+}
+
+func TestSwipeUndo(t *testing.T) {
+	const undoTimeout = 300
+	var swiped []SwipeDirection
+	item := &Item{
+		Primary: vecty.Text("Item"),
+		SwipeRight: &SwipeAction{
+			Icon:        "archive",
+			UndoTimeout: undoTimeout,
+		},
+		OnSwipe: func(i *Item, d SwipeDirection) {
+			swiped = append(swiped, d)
+		},
+	}
+	node := renderSwipeItem(item)
+
+	swipe(node, 50, 250)
+	if item.swipe.dismissed != SwipedRight {
+		t.Fatalf("dismissed = %v, want %v", item.swipe.dismissed, SwipedRight)
+	}
+	// Wait for the rerender showing the undo button.
+	time.Sleep(50 * time.Millisecond)
+	undo := node.Call("querySelector", "button")
+	if undo == nil {
+		t.Fatal("no undo button rendered")
+	}
+	undo.Call("click")
+	if item.swipe != (swipeState{}) {
+		t.Fatalf("item not reset by undo: %+v", item.swipe)
+	}
+
+	time.Sleep(2 * undoTimeout * time.Millisecond)
+	if len(swiped) != 0 {
+		t.Fatalf("OnSwipe called after undo: %v", swiped)
+	}
+	if node.Call("querySelector", "button") != nil {
+		t.Fatal("undo button still rendered after undo")
+	}
+}
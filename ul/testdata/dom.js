@@ -0,0 +1,15 @@
+// dom.js provides a jsdom document to the GopherJS tests. vecty panics in its
+// init function when there is no document, so this has to be loaded before
+// the tests run, e.g. from the root of the repository:
+//
+//   NODE_OPTIONS="--require $PWD/ul/testdata/dom.js" gopherjs test ./ul
+const { JSDOM } = require('jsdom');
+
+const dom = new JSDOM('<!DOCTYPE html><html><head></head><body></body></html>');
+global.window = dom.window;
+global.document = dom.window.document;
+global.requestAnimationFrame = cb => setTimeout(() => cb(Date.now()), 0);
+global.cancelAnimationFrame = id => clearTimeout(id);
+if (!global.performance) {
+  global.performance = dom.window.performance;
+}
@@ -0,0 +1,342 @@
+package ul
+
+import (
+	"strconv"
+
+	"agamigo.io/vecty-material/base"
+	"agamigo.io/vecty-material/icon"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+	"github.com/gopherjs/vecty/prop"
+)
+
+// Tree is a vecty-material list component for hierarchical items. The Children
+// of an expanded *Item are rendered in a nested, indented list below it.
+//
+// Tree has a roving tabindex and tree keyboard navigation: Up/Down/Home/End
+// move between visible items, Right expands an item or moves to its first
+// child, Left collapses an item or moves to its parent, and Enter/Space or a
+// click toggle an item.
+type Tree struct {
+	*base.MDC
+	vecty.Core
+	Root  vecty.MarkupOrChild
+	Items []vecty.ComponentOrHTML
+	Dense bool
+
+	// OnToggle is called when the user expands or collapses item. Expanded is
+	// already updated unless the tree is Controlled.
+	OnToggle func(thisT *Tree, item *Item)
+
+	// Controlled makes the Expanded fields of items the source of truth. User
+	// interaction only calls OnToggle, which should update the field and
+	// rerender. See base.SyncState.
+	Controlled bool
+
+	visible []*Item
+	focus   *Item
+}
+
+// groupIDs counts the IDs given to the nested lists of items.
+var groupIDs int
+
+// groupKey identifies the nested list of an item.
+type groupKey struct {
+	item *Item
+}
+
+// treeRowKey identifies a row that is not an *Item.
+type treeRowKey struct {
+	parent *Item
+	index  int
+}
+
+// Render implements the vecty.Component interface.
+func (c *Tree) Render() vecty.ComponentOrHTML {
+	rootMarkup := base.MarkupOnly(c.Root)
+	if c.Root != nil && rootMarkup == nil {
+		// User supplied root element.
+		return base.UserRoot("ul", c.Root)
+	}
+
+	c.visible = c.visible[:0]
+	rows := c.renderItems(c.Items, nil, 1)
+	if !c.isVisible(c.focus) {
+		c.focus = nil
+		for _, item := range c.visible {
+			if !item.Disabled && c.focus == nil {
+				c.focus = item
+			}
+			if !item.Disabled && (item.Activated || item.Selected) {
+				c.focus = item
+				break
+			}
+		}
+	}
+
+	root := elem.UnorderedList(rows...)
+	vecty.Markup(
+		c,
+		vecty.MarkupIf(rootMarkup != nil, *rootMarkup),
+	).Apply(root)
+	return root
+}
+
+func (c *Tree) Apply(h *vecty.HTML) {
+	switch {
+	case c.MDC == nil:
+		c.MDC = &base.MDC{}
+	}
+
+	vecty.Markup(
+		vecty.Class("mdc-list"),
+		vecty.MarkupIf(c.Dense,
+			vecty.Class("mdc-list--dense")),
+		vecty.Attribute("role", "tree"),
+		event.KeyDown(c.onKeyDown),
+		event.Click(c.onClick),
+	).Apply(h)
+	c.MDC.RootElement = h
+}
+
+// renderItems returns the keyed rows of items at the given level, followed by
+// the nested lists of the expanded ones.
+func (c *Tree) renderItems(items []vecty.ComponentOrHTML, parent *Item,
+	level int) []vecty.MarkupOrChild {
+	rows := make([]vecty.MarkupOrChild, 0, len(items))
+	for i, li := range items {
+		item, ok := li.(*Item)
+		if !ok {
+			key := treeRowKey{parent: parent, index: i}
+			rows = append(rows,
+				vecty.List{base.RenderStoredChild(li)}.WithKey(key))
+			continue
+		}
+		item.owner = c
+		item.parent = parent
+		item.level = level
+		c.visible = append(c.visible, item)
+		rows = append(rows, vecty.List{item}.WithKey(item))
+		if !item.Expanded {
+			continue
+		}
+		c.load(item)
+		if len(item.Children) == 0 {
+			continue
+		}
+		rows = append(rows, elem.ListItem(
+			vecty.Markup(
+				vecty.Key(groupKey{item: item}),
+				vecty.Attribute("role", "none"),
+			),
+			elem.UnorderedList(
+				append([]vecty.MarkupOrChild{vecty.Markup(
+					vecty.Class("mdc-list"),
+					vecty.MarkupIf(c.Dense,
+						vecty.Class("mdc-list--dense")),
+					vecty.Attribute("role", "group"),
+					prop.ID(item.treeGroupID()),
+					vecty.Style("padding", "0 0 0 16px"),
+				)}, c.renderItems(item.Children, item, level+1)...)...,
+			),
+		))
+	}
+	return rows
+}
+
+// load calls the LoadChildren callback of an expanded item that has no
+// Children yet.
+func (c *Tree) load(item *Item) {
+	if item.Children != nil {
+		item.loading = false
+		return
+	}
+	if item.LoadChildren == nil || item.loading {
+		return
+	}
+	item.loading = true
+	item.LoadChildren(item)
+	if item.Children != nil {
+		item.loading = false
+	}
+}
+
+// itemMarkup implements the itemOwner interface.
+func (c *Tree) itemMarkup(item *Item) vecty.Applyer {
+	tabindex := -1
+	if item == c.focus {
+		tabindex = 0
+	}
+	return vecty.Markup(
+		vecty.Attribute("role", "treeitem"),
+		vecty.Attribute("tabindex", tabindex),
+		vecty.Attribute("aria-level", item.level),
+		vecty.MarkupIf(item.expandable(),
+			vecty.Attribute("aria-expanded", item.Expanded)),
+		// The nested list is rendered after the item, not inside it.
+		vecty.MarkupIf(item.Expanded && len(item.Children) > 0,
+			vecty.Attribute("aria-owns", item.treeGroupID())),
+		vecty.MarkupIf(item.loading,
+			vecty.Attribute("aria-busy", "true")),
+		vecty.MarkupIf(item.Selected || item.Activated,
+			vecty.Attribute("aria-selected", "true")),
+	)
+}
+
+func (c *Tree) onKeyDown(e *vecty.Event) {
+	i := c.visibleIndexOf(e.Target)
+	if i < 0 {
+		return
+	}
+	item := c.visible[i]
+	c.focus = item
+	switch e.Get("key").String() {
+	case "ArrowDown", "Down":
+		c.focusItem(c.nextFocusable(i, 1))
+	case "ArrowUp", "Up":
+		c.focusItem(c.nextFocusable(i, -1))
+	case "Home":
+		c.focusItem(c.nextFocusable(-1, 1))
+	case "End":
+		c.focusItem(c.nextFocusable(len(c.visible), -1))
+	case "ArrowRight", "Right":
+		switch {
+		case !item.expandable():
+		case !item.Expanded:
+			c.toggle(item)
+		default:
+			for _, li := range item.Children {
+				if child, ok := li.(*Item); ok && !child.Disabled {
+					c.focusItem(child)
+					break
+				}
+			}
+		}
+	case "ArrowLeft", "Left":
+		switch {
+		case item.expandable() && item.Expanded:
+			c.toggle(item)
+		case item.parent != nil:
+			c.focusItem(item.parent)
+		}
+	case "Enter", " ", "Spacebar":
+		if !item.expandable() {
+			return
+		}
+		c.toggle(item)
+	default:
+		return
+	}
+	e.Call("preventDefault")
+}
+
+func (c *Tree) onClick(e *vecty.Event) {
+	i := c.visibleIndexOf(e.Target)
+	if i < 0 || c.visible[i].Disabled {
+		return
+	}
+	item := c.visible[i]
+	c.focus = item
+	if !item.expandable() {
+		vecty.Rerender(c)
+		return
+	}
+	c.toggle(item)
+}
+
+// toggle expands or collapses item, then reports it with OnToggle.
+func (c *Tree) toggle(item *Item) {
+	expanded := !item.Expanded
+	base.SyncState(c, c.Controlled,
+		func() { item.Expanded = expanded },
+		func() {
+			if c.OnToggle != nil {
+				c.OnToggle(c, item)
+			}
+		},
+		nil,
+	)
+}
+
+// focusItem moves the roving tabindex and the focus to item.
+func (c *Tree) focusItem(item *Item) {
+	if item == nil {
+		return
+	}
+	c.focus = item
+	focusNode(item)
+	vecty.Rerender(c)
+}
+
+// nextFocusable returns the visible item at the index nextFocusable returns,
+// or nil.
+func (c *Tree) nextFocusable(from, dir int) *Item {
+	i := nextFocusable(len(c.visible), from, dir, func(i int) bool {
+		return !c.visible[i].Disabled
+	})
+	if i < 0 {
+		return nil
+	}
+	return c.visible[i]
+}
+
+func (c *Tree) isVisible(item *Item) bool {
+	if item == nil || item.Disabled {
+		return false
+	}
+	for _, v := range c.visible {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleIndexOf returns the index in the visible items of the item containing
+// node, or -1.
+func (c *Tree) visibleIndexOf(node *js.Object) int {
+	for i, item := range c.visible {
+		if item.contains(node) {
+			return i
+		}
+	}
+	return -1
+}
+
+// treeGroupID returns the ID of the nested list of the item in a Tree.
+func (c *Item) treeGroupID() string {
+	if c.groupID == "" {
+		groupIDs++
+		c.groupID = "vecty-material-tree-group-" + strconv.Itoa(groupIDs)
+	}
+	return c.groupID
+}
+
+// expandable reports whether the item has, or can load, children.
+func (c *Item) expandable() bool {
+	return len(c.Children) > 0 || c.LoadChildren != nil
+}
+
+// renderChevron returns the expand/collapse indicator of a tree item. It takes
+// the place of the item's meta if asMeta is set.
+func (c *Item) renderChevron(asMeta bool) vecty.ComponentOrHTML {
+	if c.chevron == nil {
+		c.chevron = &icon.I{}
+	}
+	c.chevron.Name = "expand_more"
+	if c.Expanded {
+		c.chevron.Name = "expand_less"
+	}
+	return elem.Span(
+		vecty.Markup(
+			vecty.MarkupIf(asMeta,
+				vecty.Class("mdc-list-item__meta")),
+			vecty.MarkupIf(!asMeta,
+				vecty.Style("margin-left", "8px")),
+			vecty.Attribute("aria-hidden", "true"),
+		),
+		c.chevron,
+	)
+}
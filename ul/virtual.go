@@ -0,0 +1,454 @@
+package ul
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"agamigo.io/vecty-material/base"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/vecty"
+	"github.com/gopherjs/vecty/elem"
+	"github.com/gopherjs/vecty/event"
+)
+
+// VirtualL is a vecty-material list component for very long lists. It renders
+// its items inside a scrolling viewport, and only the items in and around the
+// visible part of the viewport are rendered.
+//
+// VirtualL has a roving tabindex and arrow/Home/End/PageUp/PageDown keyboard
+// navigation, which skips dividers and disabled items and scrolls the focused
+// item into view.
+type VirtualL struct {
+	*base.MDC
+	vecty.Core
+	Root vecty.MarkupOrChild
+
+	// Count is the number of items in the list.
+	Count int
+
+	// RenderItem returns the item at index i, usually an *Item or a divider
+	// such as ItemDivider(). It is called once for an item while the item is
+	// in or near the visible part of the list. Call Refresh when the items
+	// change.
+	RenderItem func(i int) vecty.ComponentOrHTML
+
+	// Height is the CSS height of the scrolling viewport. The default is
+	// "100%".
+	Height string
+
+	// ItemHeight is the height of an item in pixels. The default is the MDC
+	// height of an item of the list, which depends on TwoLine, Avatar and
+	// Dense.
+	ItemHeight float64
+
+	// Measure makes the list measure the height of each rendered item, for
+	// lists whose items (e.g. dividers) are not all ItemHeight high. Items that
+	// have not been rendered yet are assumed to be ItemHeight high.
+	Measure bool
+
+	// Overscan is the number of items rendered above and below the visible
+	// ones. The default is 3.
+	Overscan int
+
+	TwoLine        bool
+	Dense          bool
+	Avatar         bool
+	NonInteractive bool
+
+	items       map[int]vecty.ComponentOrHTML
+	heights     map[int]float64
+	offsets     offsetTree
+	generation  int
+	scrollTop   float64
+	viewport    float64
+	first, last int
+	focusIndex  int
+	listElement *vecty.HTML
+}
+
+// rowKey identifies a rendered row. generation changes on Refresh, so that
+// vecty does not reuse the components of the previous rows.
+type rowKey struct {
+	generation, index int
+}
+
+// Render implements the vecty.Component interface.
+func (c *VirtualL) Render() vecty.ComponentOrHTML {
+	rootMarkup := base.MarkupOnly(c.Root)
+	if c.Root != nil && rootMarkup == nil {
+		// User supplied root element.
+		return base.UserRoot("div", c.Root)
+	}
+
+	c.first, c.last = c.window()
+	for i := range c.items {
+		if i < c.first || i >= c.last {
+			delete(c.items, i)
+		}
+	}
+
+	rows := make([]vecty.MarkupOrChild, 0, c.last-c.first+3)
+	rows = append(rows, vecty.Markup(
+		vecty.Class("mdc-list"),
+		vecty.MarkupIf(c.TwoLine,
+			vecty.Class("mdc-list--two-line")),
+		vecty.MarkupIf(c.Dense,
+			vecty.Class("mdc-list--dense")),
+		vecty.MarkupIf(c.Avatar,
+			vecty.Class("mdc-list--avatar-list")),
+		vecty.MarkupIf(c.NonInteractive,
+			vecty.Class("mdc-list--non-interactive")),
+	))
+	rows = append(rows, spacer("top", c.offset(c.first)))
+	for i := c.first; i < c.last; i++ {
+		key := rowKey{generation: c.generation, index: i}
+		rows = append(rows, vecty.List{c.item(i)}.WithKey(key))
+	}
+	rows = append(rows,
+		spacer("bottom", c.offset(c.Count)-c.offset(c.last)))
+	c.listElement = elem.UnorderedList(rows...)
+
+	if c.Measure {
+		js.Global.Call("requestAnimationFrame", func() { c.measure() })
+	}
+
+	// Built-in root element.
+	return elem.Div(
+		vecty.Markup(
+			c,
+			vecty.MarkupIf(rootMarkup != nil, *rootMarkup),
+		),
+		c.listElement,
+	)
+}
+
+func (c *VirtualL) Apply(h *vecty.HTML) {
+	switch {
+	case c.MDC == nil:
+		c.MDC = &base.MDC{}
+	}
+
+	height := c.Height
+	if height == "" {
+		height = "100%"
+	}
+	vecty.Markup(
+		vecty.Style("overflow-y", "auto"),
+		vecty.Style("height", height),
+		event.Scroll(c.onScroll),
+		vecty.MarkupIf(!c.NonInteractive,
+			event.KeyDown(c.onKeyDown)),
+	).Apply(h)
+	c.MDC.RootElement = h
+}
+
+// Mount implements the vecty.Mounter interface. It measures the viewport and
+// renders the items that fit in it.
+func (c *VirtualL) Mount() {
+	c.MDC.Mount()
+	c.updateViewport()
+	vecty.Rerender(c)
+}
+
+// Refresh discards the rendered items and measured heights, so that
+// RenderItem is called again for the visible items. Use it after Count or the
+// items change.
+func (c *VirtualL) Refresh() {
+	c.items = nil
+	c.heights = nil
+	c.offsets = offsetTree{}
+	c.generation++
+	vecty.Rerender(c)
+}
+
+// ScrollTo scrolls the list so that the item at index i is visible.
+func (c *VirtualL) ScrollTo(i int) {
+	if i < 0 || i >= c.Count {
+		return
+	}
+	top := c.offset(i)
+	bottom := top + c.height(i)
+	switch {
+	case top < c.scrollTop:
+		c.scrollTop = top
+	case bottom > c.scrollTop+c.viewport:
+		c.scrollTop = bottom - c.viewport
+	default:
+		return
+	}
+	if c.MDC != nil && c.MDC.RootElement != nil {
+		c.MDC.RootElement.Node().Set("scrollTop", c.scrollTop)
+	}
+	vecty.Rerender(c)
+}
+
+// itemMarkup implements the itemOwner interface.
+func (c *VirtualL) itemMarkup(item *Item) vecty.Applyer {
+	if c.NonInteractive {
+		return nil
+	}
+	tabindex := -1
+	if item.index == c.focusIndex {
+		tabindex = 0
+	}
+	return vecty.Attribute("tabindex", tabindex)
+}
+
+// item returns the item at index i, calling RenderItem if it has not been
+// rendered yet.
+func (c *VirtualL) item(i int) vecty.ComponentOrHTML {
+	if c.items == nil {
+		c.items = make(map[int]vecty.ComponentOrHTML)
+	}
+	if item, ok := c.items[i]; ok {
+		return item
+	}
+	item := c.RenderItem(i)
+	if t, ok := item.(*Item); ok {
+		t.owner = c
+		t.index = i
+		if c.NonInteractive {
+			t.NoRipple = true
+		}
+	}
+	c.items[i] = item
+	return item
+}
+
+// window returns the range of items to render, [first, last).
+func (c *VirtualL) window() (first, last int) {
+	viewport := c.viewport
+	if viewport == 0 {
+		// Not mounted yet, render roughly one screen of items.
+		viewport = 10 * c.defaultHeight()
+	}
+	top, bottom := c.scrollTop, c.scrollTop+viewport
+	if len(c.heights) == 0 {
+		d := c.defaultHeight()
+		first = int(math.Floor(top / d))
+		last = int(math.Ceil(bottom / d))
+	} else {
+		first = sort.Search(c.Count, func(i int) bool {
+			return c.offset(i+1) > top
+		})
+		last = sort.Search(c.Count, func(i int) bool {
+			return c.offset(i) >= bottom
+		})
+	}
+
+	overscan := c.Overscan
+	if overscan == 0 {
+		overscan = 3
+	}
+	first -= overscan
+	if first < 0 {
+		first = 0
+	}
+	if first > c.Count {
+		first = c.Count
+	}
+	last += overscan
+	if last > c.Count {
+		last = c.Count
+	}
+	return first, last
+}
+
+// offset returns the distance in pixels from the top of the list to the item
+// at index i.
+func (c *VirtualL) offset(i int) float64 {
+	d := c.defaultHeight()
+	if len(c.heights) == 0 {
+		return float64(i) * d
+	}
+	c.offsets.reset(c.Count, d, c.heights)
+	return float64(i)*d + c.offsets.sum(i)
+}
+
+// height returns the measured height of the item at index i, or the default
+// item height if it has not been measured.
+func (c *VirtualL) height(i int) float64 {
+	if h, ok := c.heights[i]; ok {
+		return h
+	}
+	return c.defaultHeight()
+}
+
+func (c *VirtualL) defaultHeight() float64 {
+	switch {
+	case c.ItemHeight > 0:
+		return c.ItemHeight
+	case c.TwoLine && c.Dense:
+		return 60
+	case c.TwoLine:
+		return 72
+	case c.Avatar && c.Dense:
+		return 48
+	case c.Avatar:
+		return 56
+	case c.Dense:
+		return 40
+	}
+	return 48
+}
+
+// measure stores the heights of the rendered items, and rerenders the list if
+// they changed.
+func (c *VirtualL) measure() {
+	if c.listElement == nil {
+		return
+	}
+	// The first and last children are the spacers.
+	children := c.listElement.Node().Get("children")
+	changed := false
+	for k := 1; k < children.Length()-1; k++ {
+		i := c.first + k - 1
+		h := children.Index(k+1).Get("offsetTop").Float() -
+			children.Index(k).Get("offsetTop").Float()
+		if old, ok := c.heights[i]; h > 0 && (!ok || old != h) {
+			if c.heights == nil {
+				c.heights = make(map[int]float64)
+			}
+			c.offsets.add(i, h-c.height(i))
+			c.heights[i] = h
+			changed = true
+		}
+	}
+	if changed {
+		vecty.Rerender(c)
+	}
+}
+
+func (c *VirtualL) updateViewport() {
+	n := c.MDC.RootElement.Node()
+	c.scrollTop = n.Get("scrollTop").Float()
+	c.viewport = n.Get("clientHeight").Float()
+}
+
+func (c *VirtualL) onScroll(e *vecty.Event) {
+	c.updateViewport()
+	if first, last := c.window(); first != c.first || last != c.last {
+		vecty.Rerender(c)
+	}
+}
+
+func (c *VirtualL) onKeyDown(e *vecty.Event) {
+	i := c.focusIndex
+	for j, item := range c.items {
+		if t, ok := item.(*Item); ok && t.contains(e.Target) {
+			i = j
+			break
+		}
+	}
+
+	page := int(c.viewport / c.defaultHeight())
+	if page < 1 {
+		page = 1
+	}
+	next := -1
+	switch e.Get("key").String() {
+	case "ArrowDown", "Down":
+		next = c.nextFocusable(i, 1)
+	case "ArrowUp", "Up":
+		next = c.nextFocusable(i, -1)
+	case "Home":
+		next = c.nextFocusable(-1, 1)
+	case "End":
+		next = c.nextFocusable(c.Count, -1)
+	case "PageDown":
+		from := i + page
+		if from > c.Count {
+			from = c.Count
+		}
+		next = c.nextFocusable(from-1, 1)
+	case "PageUp":
+		from := i - page
+		if from < 0 {
+			from = 0
+		}
+		next = c.nextFocusable(from+1, -1)
+	default:
+		return
+	}
+	e.Call("preventDefault")
+	if next >= 0 {
+		c.focusItem(next)
+	}
+}
+
+// focusItem moves the roving tabindex to the item at index i, scrolls it into
+// view and focuses it once it is rendered.
+func (c *VirtualL) focusItem(i int) {
+	c.focusIndex = i
+	c.ScrollTo(i)
+	vecty.Rerender(c)
+	// Rendering happens in an animation frame which was requested by
+	// vecty.Rerender before this one.
+	js.Global.Call("requestAnimationFrame", func() {
+		if t, ok := c.items[i].(*Item); ok {
+			focusNode(t)
+		}
+	})
+}
+
+func (c *VirtualL) nextFocusable(from, dir int) int {
+	return nextFocusable(c.Count, from, dir, func(i int) bool {
+		t, ok := c.item(i).(*Item)
+		return ok && !t.Disabled
+	})
+}
+
+// spacer returns a keyed list element of the given height, which stands in
+// for the items that are not rendered.
+func spacer(key string, height float64) *vecty.HTML {
+	return elem.ListItem(
+		vecty.Markup(
+			vecty.Key(key),
+			vecty.Attribute("aria-hidden", "true"),
+			vecty.Style("height", strconv.FormatFloat(height, 'f', -1, 64)+"px"),
+		),
+	)
+}
+
+// offsetTree is a Fenwick tree of the differences between the measured heights
+// of the items of a VirtualL and the default item height, so that the offset
+// of an item is found in O(log n).
+type offsetTree struct {
+	tree          []float64
+	defaultHeight float64
+}
+
+// reset rebuilds the tree from heights if the number of items or the default
+// height changed since it was built.
+func (t *offsetTree) reset(count int, defaultHeight float64,
+	heights map[int]float64) {
+	if len(t.tree) == count+1 && t.defaultHeight == defaultHeight {
+		return
+	}
+	t.tree = make([]float64, count+1)
+	t.defaultHeight = defaultHeight
+	for i, h := range heights {
+		t.add(i, h-defaultHeight)
+	}
+}
+
+// add adds d to the difference of the item at index i. It does nothing before
+// the tree is built, since reset then accounts for the new height.
+func (t *offsetTree) add(i int, d float64) {
+	for i++; i > 0 && i < len(t.tree); i += i & -i {
+		t.tree[i] += d
+	}
+}
+
+// sum returns the sum of the differences of the items before index i.
+func (t *offsetTree) sum(i int) float64 {
+	if i >= len(t.tree) {
+		i = len(t.tree) - 1
+	}
+	s := 0.0
+	for ; i > 0; i -= i & -i {
+		s += t.tree[i]
+	}
+	return s
+}